@@ -0,0 +1,90 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff is a generic behavior for how retries should be performed by
+// callers such as BulkProcessor. Next is called with the number of the
+// retry being attempted (starting at 0); it returns the duration to
+// wait before the next retry, plus false if no more retries should be
+// attempted.
+type Backoff interface {
+	Next(retry int) (time.Duration, bool)
+}
+
+// StopBackoff is a Backoff that stops immediately, i.e. it never retries.
+type StopBackoff struct{}
+
+// NewStopBackoff creates a new StopBackoff.
+func NewStopBackoff() *StopBackoff {
+	return &StopBackoff{}
+}
+
+// Next implements Backoff.
+func (b *StopBackoff) Next(retry int) (time.Duration, bool) {
+	return 0, false
+}
+
+// ConstantBackoff is a Backoff that always waits the same interval
+// between retries.
+type ConstantBackoff struct {
+	interval time.Duration
+}
+
+// NewConstantBackoff creates a new ConstantBackoff.
+func NewConstantBackoff(interval time.Duration) *ConstantBackoff {
+	return &ConstantBackoff{interval: interval}
+}
+
+// Next implements Backoff.
+func (b *ConstantBackoff) Next(retry int) (time.Duration, bool) {
+	return b.interval, true
+}
+
+// ExponentialBackoff is a Backoff that increases the wait time between
+// retries exponentially, up to a maximum, with a little jitter mixed
+// in to avoid a thundering herd of retrying clients. It is computed
+// purely from the retry number passed to Next, so a single instance
+// can safely be shared across concurrent, unrelated retry sequences
+// (e.g. multiple BulkProcessor workers).
+type ExponentialBackoff struct {
+	initial time.Duration
+	max     time.Duration
+}
+
+// NewExponentialBackoff creates a new ExponentialBackoff that starts at
+// initialInterval and doubles on every retry, capped at maxInterval.
+// Next adds up to 20% jitter on top of that, so a wait can exceed
+// maxInterval by up to a fifth of it.
+func NewExponentialBackoff(initialInterval, maxInterval time.Duration) *ExponentialBackoff {
+	return &ExponentialBackoff{
+		initial: initialInterval,
+		max:     maxInterval,
+	}
+}
+
+// Next implements Backoff.
+func (b *ExponentialBackoff) Next(retry int) (time.Duration, bool) {
+	if retry < 0 {
+		retry = 0
+	}
+
+	wait := float64(b.initial) * math.Pow(2, float64(retry))
+	if !(wait > 0) || wait > float64(b.max) {
+		wait = float64(b.max)
+	}
+	d := time.Duration(wait)
+
+	// Add up to 20% jitter so that clients backing off don't all retry
+	// in lock-step.
+	jitter := time.Duration(rand.Int63n(int64(math.Max(1, float64(d)/5))))
+
+	return d + jitter, true
+}
@@ -0,0 +1,45 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoffCapsAtMax(t *testing.T) {
+	b := NewExponentialBackoff(50*time.Millisecond, 200*time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		wait, ok := b.Next(i)
+		if !ok {
+			t.Fatalf("expected Next to always allow a retry, got ok=false at retry %d", i)
+		}
+		if wait > 200*time.Millisecond+40*time.Millisecond {
+			t.Errorf("expected wait to stay near the configured max, got %v at retry %d", wait, i)
+		}
+	}
+}
+
+func TestConstantBackoff(t *testing.T) {
+	b := NewConstantBackoff(100 * time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		wait, ok := b.Next(i)
+		if !ok {
+			t.Fatalf("expected Next to always allow a retry")
+		}
+		if wait != 100*time.Millisecond {
+			t.Errorf("expected constant wait of 100ms, got %v", wait)
+		}
+	}
+}
+
+func TestStopBackoff(t *testing.T) {
+	b := NewStopBackoff()
+	if _, ok := b.Next(0); ok {
+		t.Errorf("expected StopBackoff to never allow a retry")
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// BulkableRequest is a single action (index, create, update or delete)
+// that can be added to a BulkService. Implementations render themselves
+// as one or two lines of the bulk request's NDJSON body.
+type BulkableRequest interface {
+	// Source returns the NDJSON lines (action-and-meta, and optionally
+	// source) for this request.
+	Source() ([]string, error)
+}
+
+// BulkService batches index/create/update/delete actions into a single
+// request to Elasticsearch's _bulk endpoint.
+type BulkService struct {
+	client      *Client
+	requests    []BulkableRequest
+	sizeInBytes int64
+}
+
+// NewBulkService creates a new BulkService.
+func NewBulkService(client *Client) *BulkService {
+	return &BulkService{client: client}
+}
+
+// Add appends a request to the batch.
+func (s *BulkService) Add(request BulkableRequest) *BulkService {
+	s.requests = append(s.requests, request)
+	lines, err := request.Source()
+	if err == nil {
+		for _, line := range lines {
+			s.sizeInBytes += int64(len(line)) + 1
+		}
+	}
+	return s
+}
+
+// NumberOfActions returns the number of requests batched so far.
+func (s *BulkService) NumberOfActions() int {
+	return len(s.requests)
+}
+
+// EstimatedSizeInBytes returns the estimated size of the batched
+// requests' NDJSON body.
+func (s *BulkService) EstimatedSizeInBytes() int64 {
+	return s.sizeInBytes
+}
+
+// Requests returns the requests batched so far.
+func (s *BulkService) Requests() []BulkableRequest {
+	return s.requests
+}
+
+// Reset clears the batch.
+func (s *BulkService) Reset() {
+	s.requests = nil
+	s.sizeInBytes = 0
+}
+
+// Do sends the batched requests to Elasticsearch's _bulk endpoint.
+func (s *BulkService) Do(ctx context.Context) (*BulkResponse, error) {
+	var buf bytes.Buffer
+	for _, req := range s.requests {
+		lines, err := req.Source()
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range lines {
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+	}
+
+	res, err := s.client.PerformRequest("POST", "/_bulk", url.Values{}, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	ret := new(BulkResponse)
+	if err := json.Unmarshal(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// BulkResponseItem is the per-action outcome of a bulk request.
+type BulkResponseItem struct {
+	Index  string `json:"_index"`
+	Type   string `json:"_type"`
+	Id     string `json:"_id"`
+	Status int    `json:"status"`
+	Result string `json:"result"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// BulkResponse is the response of a BulkService.Do call.
+type BulkResponse struct {
+	Took   int64                          `json:"took"`
+	Errors bool                           `json:"errors"`
+	Items  []map[string]*BulkResponseItem `json:"items"`
+}
+
+func (r *BulkResponse) byAction(action string) []*BulkResponseItem {
+	var items []*BulkResponseItem
+	for _, item := range r.Items {
+		if it, found := item[action]; found {
+			items = append(items, it)
+		}
+	}
+	return items
+}
+
+// Indexed returns all items of the "index" action.
+func (r *BulkResponse) Indexed() []*BulkResponseItem { return r.byAction("index") }
+
+// Created returns all items of the "create" action.
+func (r *BulkResponse) Created() []*BulkResponseItem { return r.byAction("create") }
+
+// Updated returns all items of the "update" action.
+func (r *BulkResponse) Updated() []*BulkResponseItem { return r.byAction("update") }
+
+// Deleted returns all items of the "delete" action.
+func (r *BulkResponse) Deleted() []*BulkResponseItem { return r.byAction("delete") }
+
+// Succeeded returns all items that completed without an error.
+func (r *BulkResponse) Succeeded() []*BulkResponseItem {
+	var items []*BulkResponseItem
+	for _, item := range r.Items {
+		for _, it := range item {
+			if it.Error == nil {
+				items = append(items, it)
+			}
+		}
+	}
+	return items
+}
+
+// Failed returns all items that failed.
+func (r *BulkResponse) Failed() []*BulkResponseItem {
+	var items []*BulkResponseItem
+	for _, item := range r.Items {
+		for _, it := range item {
+			if it.Error != nil {
+				items = append(items, it)
+			}
+		}
+	}
+	return items
+}
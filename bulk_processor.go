@@ -0,0 +1,499 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BulkBeforeFunc is called before a bulk request is committed to
+// Elasticsearch.
+type BulkBeforeFunc func(executionId int64, requests []BulkableRequest)
+
+// BulkAfterFunc is called after a bulk request has been committed to
+// Elasticsearch, or has failed to commit after all retries. When err is
+// non-nil, the commit failed outright (e.g. a network error); a non-nil
+// response with individual failed items is reported through its own
+// Failed() accessor instead.
+type BulkAfterFunc func(executionId int64, requests []BulkableRequest, response *BulkResponse, err error)
+
+// BulkProcessorService allows for easy processing of bulk requests. It
+// allows setting policies on when to flush, e.g. based on the number of
+// actions, the size of the bulk requests, or a flush interval, and
+// transparently retries transient failures with a Backoff.
+//
+// See client.BulkProcessor for details.
+type BulkProcessorService struct {
+	c             *Client
+	beforeFn      BulkBeforeFunc
+	afterFn       BulkAfterFunc
+	name          string
+	numWorkers    int
+	bulkActions   int
+	bulkSize      int
+	flushInterval time.Duration
+	wantStats     bool
+	backoff       Backoff
+}
+
+// BulkProcessor creates a new BulkProcessorService against the client's
+// cluster.
+func (c *Client) BulkProcessor() *BulkProcessorService {
+	return NewBulkProcessorService(c)
+}
+
+// NewBulkProcessorService creates a new BulkProcessorService.
+func NewBulkProcessorService(client *Client) *BulkProcessorService {
+	return &BulkProcessorService{
+		c:           client,
+		numWorkers:  1,
+		bulkActions: 1000,
+		bulkSize:    5 << 20, // 5 MB
+		backoff:     NewExponentialBackoff(50*time.Millisecond, 8*time.Second),
+		wantStats:   true,
+	}
+}
+
+// Name sets an identifier for this processor. The processor stores it
+// but does not include it in errors or callback arguments.
+func (s *BulkProcessorService) Name(name string) *BulkProcessorService {
+	s.name = name
+	return s
+}
+
+// Workers sets the number of concurrent workers that pull off the
+// internal request queue and flush to Elasticsearch.
+func (s *BulkProcessorService) Workers(num int) *BulkProcessorService {
+	s.numWorkers = num
+	return s
+}
+
+// BulkActions sets the number of requests that triggers a flush.
+func (s *BulkProcessorService) BulkActions(bulkActions int) *BulkProcessorService {
+	s.bulkActions = bulkActions
+	return s
+}
+
+// BulkSize sets the estimated size in bytes of a batch that triggers a
+// flush.
+func (s *BulkProcessorService) BulkSize(bulkSize int) *BulkProcessorService {
+	s.bulkSize = bulkSize
+	return s
+}
+
+// FlushInterval sets the interval at which a flush is forced, even if
+// neither BulkActions nor BulkSize have been reached. Disabled by default.
+func (s *BulkProcessorService) FlushInterval(interval time.Duration) *BulkProcessorService {
+	s.flushInterval = interval
+	return s
+}
+
+// Backoff sets the strategy used to retry a bulk request after a
+// transient failure such as a 429 (Too Many Requests) or 503
+// (Service Unavailable) response.
+func (s *BulkProcessorService) Backoff(backoff Backoff) *BulkProcessorService {
+	s.backoff = backoff
+	return s
+}
+
+// Before sets a callback invoked before every bulk commit.
+func (s *BulkProcessorService) Before(fn BulkBeforeFunc) *BulkProcessorService {
+	s.beforeFn = fn
+	return s
+}
+
+// After sets a callback invoked after every bulk commit, whether it
+// succeeded or failed.
+func (s *BulkProcessorService) After(fn BulkAfterFunc) *BulkProcessorService {
+	s.afterFn = fn
+	return s
+}
+
+// Stats specifies whether the processor should keep track of the
+// per-item counters (Indexed, Created, Updated, Deleted, Succeeded and
+// Failed). Flushed, Committed and the workers' Queued depths are kept
+// up to date regardless. Enabled by default.
+func (s *BulkProcessorService) Stats(wantStats bool) *BulkProcessorService {
+	s.wantStats = wantStats
+	return s
+}
+
+// Do creates and starts a new BulkProcessor.
+func (s *BulkProcessorService) Do(ctx context.Context) (*BulkProcessor, error) {
+	p := newBulkProcessor(
+		s.c,
+		s.beforeFn,
+		s.afterFn,
+		s.name,
+		s.numWorkers,
+		s.bulkActions,
+		s.bulkSize,
+		s.flushInterval,
+		s.wantStats,
+		s.backoff,
+	)
+	if err := p.Start(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// -- BulkProcessor --
+
+// BulkProcessor simplifies the use of the Bulk API. Documents are added
+// via Add and are flushed to Elasticsearch automatically once one of
+// the configured thresholds (action count, byte size, or flush
+// interval) is reached. Create one via Client.BulkProcessor.
+type BulkProcessor struct {
+	c             *Client
+	beforeFn      BulkBeforeFunc
+	afterFn       BulkAfterFunc
+	name          string
+	bulkActions   int
+	bulkSize      int
+	numWorkers    int
+	flushInterval time.Duration
+	wantStats     bool
+	backoff       Backoff
+
+	startedMu sync.Mutex
+	started   bool
+
+	closedMu sync.RWMutex
+	closed   bool
+
+	executionId int64
+	requestsC   chan BulkableRequest
+	workerWg    sync.WaitGroup
+	workers     []*bulkWorker
+
+	flushStopC chan struct{}
+
+	statsMu sync.Mutex
+	stats   *BulkProcessorStats
+}
+
+func newBulkProcessor(
+	client *Client,
+	beforeFn BulkBeforeFunc,
+	afterFn BulkAfterFunc,
+	name string,
+	numWorkers int,
+	bulkActions int,
+	bulkSize int,
+	flushInterval time.Duration,
+	wantStats bool,
+	backoff Backoff,
+) *BulkProcessor {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	return &BulkProcessor{
+		c:             client,
+		beforeFn:      beforeFn,
+		afterFn:       afterFn,
+		name:          name,
+		numWorkers:    numWorkers,
+		bulkActions:   bulkActions,
+		bulkSize:      bulkSize,
+		flushInterval: flushInterval,
+		wantStats:     wantStats,
+		backoff:       backoff,
+		stats:         newBulkProcessorStats(numWorkers),
+	}
+}
+
+// Start starts the background workers and, if a FlushInterval was
+// configured, the periodic flusher. It is idempotent; calling it twice
+// has no effect.
+func (p *BulkProcessor) Start(ctx context.Context) error {
+	p.startedMu.Lock()
+	defer p.startedMu.Unlock()
+
+	if p.started {
+		return nil
+	}
+
+	p.requestsC = make(chan BulkableRequest)
+	p.workers = make([]*bulkWorker, p.numWorkers)
+	for i := 0; i < p.numWorkers; i++ {
+		w := newBulkWorker(p, i)
+		p.workers[i] = w
+		p.workerWg.Add(1)
+		go w.work(ctx)
+	}
+
+	if p.flushInterval > 0 {
+		p.flushStopC = make(chan struct{})
+		go p.flusher(p.flushInterval)
+	}
+
+	p.started = true
+	return nil
+}
+
+// Stop is an alias for Close.
+func (p *BulkProcessor) Stop() error {
+	return p.Close()
+}
+
+// Close stops the background workers, flushing any pending documents
+// first.
+func (p *BulkProcessor) Close() error {
+	p.startedMu.Lock()
+	defer p.startedMu.Unlock()
+
+	if !p.started {
+		return nil
+	}
+
+	p.closedMu.Lock()
+	p.closed = true
+	p.closedMu.Unlock()
+
+	if p.flushStopC != nil {
+		close(p.flushStopC)
+	}
+
+	close(p.requestsC)
+	p.workerWg.Wait()
+
+	p.started = false
+	return nil
+}
+
+// Add adds a single request to the queue for bulking. It may flush
+// the queue if BulkActions or BulkSize are reached as a result. Add is
+// a no-op once Close has been called.
+func (p *BulkProcessor) Add(request BulkableRequest) {
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+	if p.closed {
+		return
+	}
+	p.requestsC <- request
+}
+
+// Flush manually asks all workers to commit their pending bulk
+// requests, regardless of whether the configured thresholds have been
+// reached. Each worker's bulk service is only ever touched from its own
+// worker goroutine, so Flush hands the request off through the same
+// channel work uses rather than calling into it directly.
+func (p *BulkProcessor) Flush() error {
+	var firstErr error
+	for _, w := range p.workers {
+		reply := make(chan error, 1)
+		w.flushC <- reply
+		if err := <-reply; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Stats returns the latest snapshot of operational statistics.
+func (p *BulkProcessor) Stats() BulkProcessorStats {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	return p.stats.clone()
+}
+
+func (p *BulkProcessor) flusher(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.flushStopC:
+			return
+		case <-ticker.C:
+			p.Flush()
+		}
+	}
+}
+
+func (p *BulkProcessor) nextExecutionId() int64 {
+	return atomic.AddInt64(&p.executionId, 1)
+}
+
+// -- Stats --
+
+// BulkProcessorStats gives insight into the state of a BulkProcessor.
+type BulkProcessorStats struct {
+	Flushed   int64
+	Committed int64
+	Indexed   int64
+	Created   int64
+	Updated   int64
+	Deleted   int64
+	Succeeded int64
+	Failed    int64
+
+	Workers []BulkProcessorWorkerStats
+}
+
+// BulkProcessorWorkerStats reports the current queue depth of a single
+// BulkProcessor worker.
+type BulkProcessorWorkerStats struct {
+	Queued int64
+}
+
+func newBulkProcessorStats(workers int) *BulkProcessorStats {
+	return &BulkProcessorStats{
+		Workers: make([]BulkProcessorWorkerStats, workers),
+	}
+}
+
+func (s *BulkProcessorStats) clone() BulkProcessorStats {
+	clone := *s
+	clone.Workers = append([]BulkProcessorWorkerStats(nil), s.Workers...)
+	return clone
+}
+
+// -- Worker --
+
+// bulkWorker pulls requests off the processor's shared channel,
+// accumulates them into a batch, and commits the batch whenever
+// BulkActions, BulkSize or an explicit Flush is triggered. Its
+// bulkService is only ever read or mutated from within work, so Flush
+// talks to it through flushC instead of reaching in directly.
+type bulkWorker struct {
+	p           *BulkProcessor
+	i           int
+	bulkService *BulkService
+	flushC      chan chan error
+}
+
+func newBulkWorker(p *BulkProcessor, i int) *bulkWorker {
+	return &bulkWorker{
+		p:           p,
+		i:           i,
+		bulkService: NewBulkService(p.c),
+		flushC:      make(chan chan error),
+	}
+}
+
+func (w *bulkWorker) work(ctx context.Context) {
+	defer w.p.workerWg.Done()
+
+	for {
+		select {
+		case req, open := <-w.p.requestsC:
+			if !open {
+				if w.bulkService.NumberOfActions() > 0 {
+					w.commit(ctx)
+				}
+				return
+			}
+
+			w.bulkService.Add(req)
+
+			w.p.statsMu.Lock()
+			w.p.stats.Workers[w.i].Queued = int64(w.bulkService.NumberOfActions())
+			w.p.statsMu.Unlock()
+
+			if w.commitRequired() {
+				w.commit(ctx)
+			}
+
+		case reply := <-w.flushC:
+			var err error
+			if w.bulkService.NumberOfActions() > 0 {
+				err = w.commit(ctx)
+			}
+			reply <- err
+		}
+	}
+}
+
+func (w *bulkWorker) commitRequired() bool {
+	if w.p.bulkActions > 0 && w.bulkService.NumberOfActions() >= w.p.bulkActions {
+		return true
+	}
+	if w.p.bulkSize > 0 && w.bulkService.EstimatedSizeInBytes() >= int64(w.p.bulkSize) {
+		return true
+	}
+	return false
+}
+
+func (w *bulkWorker) commit(ctx context.Context) error {
+	requests := w.bulkService.Requests()
+	if len(requests) == 0 {
+		return nil
+	}
+
+	id := w.p.nextExecutionId()
+
+	if w.p.beforeFn != nil {
+		w.p.beforeFn(id, requests)
+	}
+
+	var res *BulkResponse
+	var err error
+
+	for retry := 0; ; retry++ {
+		res, err = w.bulkService.Do(ctx)
+		if err == nil || !isRetryableBulkError(err) || w.p.backoff == nil {
+			break
+		}
+		wait, ok := w.p.backoff.Next(retry)
+		if !ok {
+			break
+		}
+		time.Sleep(wait)
+	}
+
+	if w.p.afterFn != nil {
+		w.p.afterFn(id, requests, res, err)
+	}
+
+	if w.p.wantStats {
+		w.updateStats(res)
+	}
+
+	w.bulkService.Reset()
+
+	w.p.statsMu.Lock()
+	w.p.stats.Workers[w.i].Queued = 0
+	w.p.stats.Flushed++
+	if err == nil {
+		w.p.stats.Committed++
+	}
+	w.p.statsMu.Unlock()
+
+	return err
+}
+
+func (w *bulkWorker) updateStats(res *BulkResponse) {
+	if res == nil {
+		return
+	}
+	w.p.statsMu.Lock()
+	defer w.p.statsMu.Unlock()
+	w.p.stats.Indexed += int64(len(res.Indexed()))
+	w.p.stats.Created += int64(len(res.Created()))
+	w.p.stats.Updated += int64(len(res.Updated()))
+	w.p.stats.Deleted += int64(len(res.Deleted()))
+	w.p.stats.Succeeded += int64(len(res.Succeeded()))
+	w.p.stats.Failed += int64(len(res.Failed()))
+}
+
+// isRetryableBulkError reports whether err looks like a transient
+// failure (e.g. a 429 Too Many Requests or 503 Service Unavailable)
+// that is worth retrying with backoff.
+func isRetryableBulkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Status == 429 || e.Status == 503
+	}
+	return false
+}
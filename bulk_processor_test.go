@@ -0,0 +1,129 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// fakeBulkableRequest is a minimal BulkableRequest used by tests that
+// need a working request without exercising a real index/update/delete
+// action.
+type fakeBulkableRequest struct{}
+
+func (r fakeBulkableRequest) Source() ([]string, error) {
+	return []string{`{"index":{}}`, `{}`}, nil
+}
+
+// newTestBulkClient returns a Client backed by an httptest.Server that
+// answers every request with an empty, successful bulk response, along
+// with a func to tear the server down.
+func newTestBulkClient(t *testing.T) (*Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"took":0,"errors":false,"items":[]}`))
+	}))
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		server.Close()
+		t.Fatalf("failed to create test client: %v", err)
+	}
+
+	return client, server.Close
+}
+
+func TestBulkProcessorServiceDefaults(t *testing.T) {
+	s := NewBulkProcessorService(nil)
+	if s.numWorkers != 1 {
+		t.Errorf("expected 1 worker by default, got %d", s.numWorkers)
+	}
+	if s.bulkActions != 1000 {
+		t.Errorf("expected bulkActions = 1000 by default, got %d", s.bulkActions)
+	}
+	if s.bulkSize != 5<<20 {
+		t.Errorf("expected bulkSize = 5MB by default, got %d", s.bulkSize)
+	}
+	if !s.wantStats {
+		t.Errorf("expected wantStats = true by default")
+	}
+	if s.backoff == nil {
+		t.Errorf("expected a default Backoff to be set")
+	}
+}
+
+func TestBulkProcessorAddAfterClose(t *testing.T) {
+	client, teardown := newTestBulkClient(t)
+	defer teardown()
+
+	p, err := NewBulkProcessorService(client).Workers(2).Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Add must not panic with a "send on closed channel" once the
+	// processor has been closed; it should simply be a no-op.
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Add after Close panicked: %v", r)
+		}
+	}()
+	p.Add(fakeBulkableRequest{})
+}
+
+// TestBulkProcessorConcurrentAddAndFlush adds requests and calls Flush
+// concurrently to guard against a worker's bulkService being touched
+// from more than one goroutine at a time (run with -race).
+func TestBulkProcessorConcurrentAddAndFlush(t *testing.T) {
+	client, teardown := newTestBulkClient(t)
+	defer teardown()
+
+	p, err := NewBulkProcessorService(client).Workers(2).BulkActions(3).Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			p.Add(fakeBulkableRequest{})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if err := p.Flush(); err != nil {
+				t.Errorf("Flush failed: %v", err)
+			}
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestBulkProcessorStatsClone(t *testing.T) {
+	stats := newBulkProcessorStats(2)
+	stats.Workers[0].Queued = 5
+
+	clone := stats.clone()
+	clone.Workers[0].Queued = 9
+
+	if stats.Workers[0].Queued != 5 {
+		t.Errorf("expected clone to be independent of the original, but mutating the clone changed it to %d", stats.Workers[0].Queued)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Client is a handle to an Elasticsearch cluster. It is the entry
+// point for building and executing requests, e.g. client.Search(),
+// client.Index(), client.Flush() or client.BulkProcessor().
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient creates a new Client that talks to the Elasticsearch
+// instance at url (e.g. "http://localhost:9200").
+func NewClient(url string) (*Client, error) {
+	if url == "" {
+		return nil, fmt.Errorf("elastic: url must not be empty")
+	}
+	return &Client{
+		url:        url,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+// Response is the raw result of a request performed against
+// Elasticsearch.
+type Response struct {
+	StatusCode int
+	Body       []byte
+}
+
+// PerformRequest sends an HTTP request with the given method, path,
+// query parameters and JSON-serializable body to Elasticsearch, and
+// returns its raw response. A non-2xx response is returned as an
+// *Error via errors.As-compatible wrapping.
+func (c *Client) PerformRequest(method, path string, params url.Values, body interface{}) (*Response, error) {
+	if c == nil {
+		return nil, fmt.Errorf("elastic: PerformRequest called on a nil Client")
+	}
+
+	u := c.url + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		if raw, ok := body.([]byte); ok {
+			reqBody = bytes.NewReader(raw)
+		} else {
+			data, err := json.Marshal(body)
+			if err != nil {
+				return nil, err
+			}
+			reqBody = bytes.NewReader(data)
+		}
+	}
+
+	req, err := http.NewRequest(method, u, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 300 {
+		e := &Error{Status: res.StatusCode}
+		if len(data) > 0 {
+			_ = json.Unmarshal(data, e)
+		}
+		return nil, e
+	}
+
+	return &Response{StatusCode: res.StatusCode, Body: data}, nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateFilter translates user-facing date flags -- After, Before and On --
+// into a RangeQuery against a single timestamp field. It implements the
+// Query interface, so it is passed straight to SearchService.Query, e.g.:
+//
+//	q := NewDateFilter().Field("created").After("2012-01-01").Before("2013-01-01")
+//	searchResult, err := client.Search().Index("twitter").Query(q).Do()
+//
+// The package has no BoolQuery, so a DateFilter is not combined with a
+// second query (e.g. a full-text match) in the same search request.
+type DateFilter struct {
+	field     string
+	afterRaw  string
+	beforeRaw string
+	onRaw     string
+}
+
+// NewDateFilter creates a new DateFilter on the "created" field. Use
+// Field to target a different timestamp field.
+func NewDateFilter() *DateFilter {
+	return &DateFilter{field: "created"}
+}
+
+// Field sets the timestamp field the filter is applied to.
+func (f *DateFilter) Field(field string) *DateFilter {
+	f.field = field
+	return f
+}
+
+// After restricts results to documents on or after the given date, in
+// YYYY-MM-DD form (month and day may be unpadded, e.g. "2012-1-5").
+func (f *DateFilter) After(date string) *DateFilter {
+	f.afterRaw = date
+	return f
+}
+
+// Before restricts results to documents strictly before the given date.
+func (f *DateFilter) Before(date string) *DateFilter {
+	f.beforeRaw = date
+	return f
+}
+
+// On restricts results to documents within the half-open day range
+// [date, date+24h) in UTC. It takes precedence over After and Before.
+func (f *DateFilter) On(date string) *DateFilter {
+	f.onRaw = date
+	return f
+}
+
+// IsEmpty returns true if none of After, Before or On have been set.
+func (f *DateFilter) IsEmpty() bool {
+	return f.afterRaw == "" && f.beforeRaw == "" && f.onRaw == ""
+}
+
+// rangeQuery builds the underlying RangeQuery, parsing the configured
+// date flags.
+func (f *DateFilter) rangeQuery() (*RangeQuery, error) {
+	rq := NewRangeQuery(f.field)
+
+	if f.onRaw != "" {
+		day, err := parseDateFilterDate(f.onRaw)
+		if err != nil {
+			return nil, err
+		}
+		rq = rq.Gte(formatDateFilterDate(day)).Lt(formatDateFilterDate(day.Add(24 * time.Hour)))
+		return rq, nil
+	}
+
+	if f.afterRaw != "" {
+		after, err := parseDateFilterDate(f.afterRaw)
+		if err != nil {
+			return nil, err
+		}
+		rq = rq.Gte(formatDateFilterDate(after))
+	}
+	if f.beforeRaw != "" {
+		before, err := parseDateFilterDate(f.beforeRaw)
+		if err != nil {
+			return nil, err
+		}
+		rq = rq.Lt(formatDateFilterDate(before))
+	}
+
+	return rq, nil
+}
+
+// Source returns JSON for the query.
+func (f *DateFilter) Source() (interface{}, error) {
+	rq, err := f.rangeQuery()
+	if err != nil {
+		return nil, err
+	}
+	return rq.Source()
+}
+
+// parseDateFilterDate parses an ISO-8601 YYYY-MM-DD date, with month and
+// day zero-padding optional, into a UTC midnight time.Time.
+func parseDateFilterDate(date string) (time.Time, error) {
+	parts := strings.Split(date, "-")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf(`elastic: invalid date %q, expected "YYYY-MM-DD"`, date)
+	}
+
+	year, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("elastic: invalid year in date %q: %v", date, err)
+	}
+	month, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("elastic: invalid month in date %q: %v", date, err)
+	}
+	if month < 1 || month > 12 {
+		return time.Time{}, fmt.Errorf("elastic: invalid month %d in date %q, expected 1-12", month, date)
+	}
+	day, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("elastic: invalid day in date %q: %v", date, err)
+	}
+	if max := daysInMonth(year, month); day < 1 || day > max {
+		return time.Time{}, fmt.Errorf("elastic: invalid day %d in date %q, expected 1-%d", day, date, max)
+	}
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// daysInMonth returns the number of days in the given month of year,
+// accounting for leap years. month must be in 1-12.
+func daysInMonth(year, month int) int {
+	switch month {
+	case 1, 3, 5, 7, 8, 10, 12:
+		return 31
+	case 4, 6, 9, 11:
+		return 30
+	default: // February
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	}
+}
+
+func formatDateFilterDate(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05Z")
+}
+
+// ParseDateFilterFlags scans input for "after:YYYY-MM-DD", "before:YYYY-MM-DD"
+// and "on:YYYY-MM-DD" flags, removes them, and returns the residual
+// free-text together with a DateFilter built from whatever flags were
+// found. The residual is the remaining words joined by single spaces, so
+// runs of whitespace in the input are collapsed. If no flags were found,
+// the returned DateFilter's IsEmpty reports true.
+func ParseDateFilterFlags(input string) (residual string, filter *DateFilter, err error) {
+	filter = NewDateFilter()
+
+	var remaining []string
+	for _, token := range strings.Fields(input) {
+		switch {
+		case strings.HasPrefix(token, "after:"):
+			filter.After(strings.TrimPrefix(token, "after:"))
+		case strings.HasPrefix(token, "before:"):
+			filter.Before(strings.TrimPrefix(token, "before:"))
+		case strings.HasPrefix(token, "on:"):
+			filter.On(strings.TrimPrefix(token, "on:"))
+		default:
+			remaining = append(remaining, token)
+		}
+	}
+
+	if !filter.IsEmpty() {
+		if _, err = filter.rangeQuery(); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return strings.Join(remaining, " "), filter, nil
+}
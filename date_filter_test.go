@@ -0,0 +1,124 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDateFilterAfterBefore(t *testing.T) {
+	f := NewDateFilter().Field("created").After("2012-01-01").Before("2013-01-01")
+	src, err := f.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"range":{"created":{"from":"2012-01-01T00:00:00Z","include_lower":true,"include_upper":false,"to":"2013-01-01T00:00:00Z"}}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestDateFilterOn(t *testing.T) {
+	f := NewDateFilter().Field("created").On("2012-12-12")
+	src, err := f.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"range":{"created":{"from":"2012-12-12T00:00:00Z","include_lower":true,"include_upper":false,"to":"2012-12-13T00:00:00Z"}}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestDateFilterUnpaddedMonthAndDay(t *testing.T) {
+	padded := NewDateFilter().Field("created").After("2012-01-05")
+	unpadded := NewDateFilter().Field("created").After("2012-1-5")
+
+	paddedSrc, err := padded.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpaddedSrc, err := unpadded.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paddedData, _ := json.Marshal(paddedSrc)
+	unpaddedData, _ := json.Marshal(unpaddedSrc)
+	if string(paddedData) != string(unpaddedData) {
+		t.Errorf("expected %q and %q to parse to the same range query", "2012-01-05", "2012-1-5")
+	}
+}
+
+func TestParseDateFilterFlags(t *testing.T) {
+	residual, filter, err := ParseDateFilterFlags("after:2012-01-01 before:2013-01-01 elasticsearch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if residual != "elasticsearch" {
+		t.Errorf("expected residual %q, got %q", "elasticsearch", residual)
+	}
+	if filter.IsEmpty() {
+		t.Errorf("expected filter to not be empty")
+	}
+
+	src, err := filter.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(src)
+	got := string(data)
+	expected := `{"range":{"created":{"from":"2012-01-01T00:00:00Z","include_lower":true,"include_upper":false,"to":"2013-01-01T00:00:00Z"}}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestDateFilterInvalidDate(t *testing.T) {
+	tests := []string{
+		"2012-13-01", // invalid month
+		"2012-00-01", // invalid month
+		"2012-02-30", // February never has 30 days
+		"2013-02-29", // 2013 is not a leap year
+		"2012-04-31", // April has 30 days
+	}
+	for _, date := range tests {
+		f := NewDateFilter().Field("created").After(date)
+		if _, err := f.Source(); err == nil {
+			t.Errorf("expected an error for invalid date %q, got none", date)
+		}
+	}
+}
+
+func TestDateFilterLeapYear(t *testing.T) {
+	f := NewDateFilter().Field("created").After("2012-02-29")
+	if _, err := f.Source(); err != nil {
+		t.Errorf("expected 2012-02-29 to be valid (2012 is a leap year), got error: %v", err)
+	}
+}
+
+func TestParseDateFilterFlagsWithoutFlags(t *testing.T) {
+	residual, filter, err := ParseDateFilterFlags("just a search query")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if residual != "just a search query" {
+		t.Errorf("expected residual %q, got %q", "just a search query", residual)
+	}
+	if !filter.IsEmpty() {
+		t.Errorf("expected filter to be empty")
+	}
+}
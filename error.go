@@ -0,0 +1,21 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "fmt"
+
+// Error encapsulates error details as returned by Elasticsearch.
+type Error struct {
+	Status  int    `json:"status"`
+	Message string `json:"error"`
+}
+
+// Error returns a string representation of the error.
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("elastic: Error %d (%s)", e.Status, e.Message)
+	}
+	return fmt.Sprintf("elastic: Error %d", e.Status)
+}
@@ -0,0 +1,14 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// Facet is the legacy predecessor to Aggregation. It is anything that
+// knows how to render itself into a JSON-serializable facet clause,
+// e.g. TermsFacet, RangeFacet or HistogramFacet. Prefer Aggregation for
+// new code; Facet remains for backwards compatibility.
+type Facet interface {
+	// Source returns the JSON-serializable facet.
+	Source() (interface{}, error)
+}
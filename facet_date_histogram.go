@@ -0,0 +1,56 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// DateHistogramFacet buckets documents into fixed time intervals based
+// on a date field, e.g.:
+//
+//	f := NewDateHistogramFacet("created").Interval("year")
+type DateHistogramFacet struct {
+	keyField   string
+	valueField string
+	interval   string
+}
+
+// NewDateHistogramFacet creates and initializes a new DateHistogramFacet
+// bucketing on field.
+func NewDateHistogramFacet(field string) *DateHistogramFacet {
+	return &DateHistogramFacet{keyField: field}
+}
+
+// Interval sets the bucket width, e.g. "year", "month" or "day".
+func (f *DateHistogramFacet) Interval(interval string) *DateHistogramFacet {
+	f.interval = interval
+	return f
+}
+
+// KeyField overrides the field used to compute the bucket key.
+func (f *DateHistogramFacet) KeyField(field string) *DateHistogramFacet {
+	f.keyField = field
+	return f
+}
+
+// ValueField sets a separate field whose values are aggregated
+// (min/max/total/mean) within each bucket.
+func (f *DateHistogramFacet) ValueField(field string) *DateHistogramFacet {
+	f.valueField = field
+	return f
+}
+
+// Source returns JSON for the facet.
+func (f *DateHistogramFacet) Source() (interface{}, error) {
+	opts := make(map[string]interface{})
+	opts["key_field"] = f.keyField
+	if f.valueField != "" {
+		opts["value_field"] = f.valueField
+	}
+	if f.interval != "" {
+		opts["interval"] = f.interval
+	}
+
+	return map[string]interface{}{
+		"date_histogram": opts,
+	}, nil
+}
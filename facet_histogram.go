@@ -0,0 +1,47 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// HistogramFacet buckets documents into fixed-size numeric or time
+// intervals, e.g.:
+//
+//	f := NewHistogramFacet("retweets").Interval(100)
+type HistogramFacet struct {
+	field        string
+	interval     int64
+	timeInterval string
+}
+
+// NewHistogramFacet creates and initializes a new HistogramFacet on field.
+func NewHistogramFacet(field string) *HistogramFacet {
+	return &HistogramFacet{field: field}
+}
+
+// Interval sets the bucket width as a fixed numeric value.
+func (f *HistogramFacet) Interval(interval int64) *HistogramFacet {
+	f.interval = interval
+	return f
+}
+
+// TimeInterval sets the bucket width as a time value, e.g. "1m" or "1d".
+func (f *HistogramFacet) TimeInterval(interval string) *HistogramFacet {
+	f.timeInterval = interval
+	return f
+}
+
+// Source returns JSON for the facet.
+func (f *HistogramFacet) Source() (interface{}, error) {
+	opts := make(map[string]interface{})
+	opts["field"] = f.field
+	if f.timeInterval != "" {
+		opts["time_interval"] = f.timeInterval
+	} else {
+		opts["interval"] = f.interval
+	}
+
+	return map[string]interface{}{
+		"histogram": opts,
+	}, nil
+}
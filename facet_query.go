@@ -0,0 +1,54 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// QueryFacet computes a simple document count matching an arbitrary
+// query, e.g.:
+//
+//	f := NewQueryFacet(NewTermQuery("user", "olivere")).Global(true)
+type QueryFacet struct {
+	query  Query
+	global bool
+	order  string
+}
+
+// NewQueryFacet creates and initializes a new QueryFacet.
+func NewQueryFacet(query Query) *QueryFacet {
+	return &QueryFacet{query: query}
+}
+
+// Global, if enabled, computes the facet across all documents in the
+// index, ignoring the main query.
+func (f *QueryFacet) Global(global bool) *QueryFacet {
+	f.global = global
+	return f
+}
+
+// Order sets the ordering of the facet's results.
+func (f *QueryFacet) Order(order string) *QueryFacet {
+	f.order = order
+	return f
+}
+
+// Source returns JSON for the facet.
+func (f *QueryFacet) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+
+	if f.query != nil {
+		src, err := f.query.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["query"] = src
+	}
+	if f.global {
+		source["global"] = true
+	}
+	if f.order != "" {
+		source["order"] = f.order
+	}
+
+	return source, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// RangeFacet buckets documents into a list of user-defined ranges, e.g.:
+//
+//	f := NewRangeFacet("retweets").Lt(10).Between(10, 100).Gt(100)
+type RangeFacet struct {
+	field  string
+	ranges []rangeFacetEntry
+}
+
+type rangeFacetEntry struct {
+	from interface{}
+	to   interface{}
+}
+
+// NewRangeFacet creates and initializes a new RangeFacet on field.
+func NewRangeFacet(field string) *RangeFacet {
+	return &RangeFacet{field: field}
+}
+
+// Lt adds a range matching values less than to.
+func (f *RangeFacet) Lt(to float64) *RangeFacet {
+	return f.AddRange(nil, to)
+}
+
+// Between adds a range matching values in [from, to).
+func (f *RangeFacet) Between(from, to float64) *RangeFacet {
+	return f.AddRange(from, to)
+}
+
+// Gt adds a range matching values greater than or equal to from.
+func (f *RangeFacet) Gt(from float64) *RangeFacet {
+	return f.AddRange(from, nil)
+}
+
+// AddRange adds a range with an open-ended from and/or to.
+func (f *RangeFacet) AddRange(from, to interface{}) *RangeFacet {
+	f.ranges = append(f.ranges, rangeFacetEntry{from: from, to: to})
+	return f
+}
+
+// Source returns JSON for the facet.
+func (f *RangeFacet) Source() (interface{}, error) {
+	ranges := make([]interface{}, 0, len(f.ranges))
+	for _, r := range f.ranges {
+		entry := make(map[string]interface{})
+		if r.from != nil {
+			entry["from"] = r.from
+		}
+		if r.to != nil {
+			entry["to"] = r.to
+		}
+		ranges = append(ranges, entry)
+	}
+
+	return map[string]interface{}{
+		"range": map[string]interface{}{
+			"field":  f.field,
+			"ranges": ranges,
+		},
+	}, nil
+}
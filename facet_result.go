@@ -0,0 +1,56 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SearchFacets is a map of facet name to its computed result, keyed the
+// same way the facets were added to the search request via
+// SearchService.Facet.
+type SearchFacets map[string]*SearchFacet
+
+// SearchFacet is the outcome of a single Facet computation. Which of
+// Terms, Ranges and Entries is populated depends on the kind of facet
+// that produced it (Type reports "terms", "range", "histogram" or
+// "date_histogram").
+type SearchFacet struct {
+	Type    string                 `json:"_type"`
+	Missing int64                  `json:"missing"`
+	Total   int64                  `json:"total"`
+	Other   int64                  `json:"other"`
+	Terms   []*TermsFacetTerm      `json:"terms"`
+	Ranges  []*RangeFacetRange     `json:"ranges"`
+	Entries []*HistogramFacetEntry `json:"entries"`
+}
+
+// TermsFacetTerm is a single bucket of a TermsFacet result.
+type TermsFacetTerm struct {
+	Term  interface{} `json:"term"`
+	Count int64       `json:"count"`
+}
+
+// RangeFacetRange is a single bucket of a RangeFacet result.
+type RangeFacetRange struct {
+	From       *float64 `json:"from"`
+	To         *float64 `json:"to"`
+	Count      int64    `json:"count"`
+	Min        *float64 `json:"min"`
+	Max        *float64 `json:"max"`
+	Total      *float64 `json:"total"`
+	TotalCount int64    `json:"total_count"`
+	Mean       *float64 `json:"mean"`
+}
+
+// HistogramFacetEntry is a single bucket of a HistogramFacet or
+// DateHistogramFacet result. Key is populated for a plain histogram;
+// Time (milliseconds since epoch) is populated for a date histogram.
+type HistogramFacetEntry struct {
+	Key        interface{} `json:"key"`
+	Time       int64       `json:"time"`
+	Count      int64       `json:"count"`
+	Min        interface{} `json:"min"`
+	Max        interface{} `json:"max"`
+	Total      float64     `json:"total"`
+	TotalCount int64       `json:"total_count"`
+	Mean       float64     `json:"mean"`
+}
@@ -0,0 +1,99 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// TermsFacet computes the most frequent values for a given field, akin
+// to a (legacy) terms aggregation.
+type TermsFacet struct {
+	field       string
+	size        *int
+	order       string
+	allTerms    bool
+	exclude     []string
+	regex       string
+	regexFlags  string
+	facetFilter Query
+}
+
+// NewTermsFacet creates and initializes a new TermsFacet on field.
+func NewTermsFacet(field string) *TermsFacet {
+	return &TermsFacet{field: field}
+}
+
+// Size sets the number of terms to return.
+func (f *TermsFacet) Size(size int) *TermsFacet {
+	f.size = &size
+	return f
+}
+
+// Order sets the ordering of the terms, e.g. "count", "term",
+// "reverse_count" or "reverse_term".
+func (f *TermsFacet) Order(order string) *TermsFacet {
+	f.order = order
+	return f
+}
+
+// AllTerms, if enabled, returns all terms, including ones not matching
+// any document.
+func (f *TermsFacet) AllTerms(allTerms bool) *TermsFacet {
+	f.allTerms = allTerms
+	return f
+}
+
+// Exclude sets terms that should be excluded from the facet.
+func (f *TermsFacet) Exclude(exclude ...string) *TermsFacet {
+	f.exclude = append(f.exclude, exclude...)
+	return f
+}
+
+// Regex restricts the terms considered to those matching the regex.
+func (f *TermsFacet) Regex(regex, flags string) *TermsFacet {
+	f.regex = regex
+	f.regexFlags = flags
+	return f
+}
+
+// FacetFilter restricts the set of documents the facet is computed on.
+func (f *TermsFacet) FacetFilter(filter Query) *TermsFacet {
+	f.facetFilter = filter
+	return f
+}
+
+// Source returns JSON for the facet.
+func (f *TermsFacet) Source() (interface{}, error) {
+	opts := make(map[string]interface{})
+	terms := make(map[string]interface{})
+	opts["terms"] = terms
+
+	terms["field"] = f.field
+	if f.size != nil {
+		terms["size"] = *f.size
+	}
+	if f.order != "" {
+		terms["order"] = f.order
+	}
+	if f.allTerms {
+		terms["all_terms"] = true
+	}
+	if len(f.exclude) > 0 {
+		terms["exclude"] = f.exclude
+	}
+	if f.regex != "" {
+		terms["regex"] = f.regex
+		if f.regexFlags != "" {
+			terms["regex_flags"] = f.regexFlags
+		}
+	}
+
+	if f.facetFilter != nil {
+		src, err := f.facetFilter.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["facet_filter"] = src
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FlushService flushes one or more indices, forcing any data held in
+// the transaction log to be committed to the index, e.g.:
+//
+//	_, err := client.Flush().Index("twitter").Do()
+type FlushService struct {
+	client  *Client
+	indices []string
+}
+
+// Flush creates a new FlushService against the client's cluster.
+func (c *Client) Flush() *FlushService {
+	return &FlushService{client: c}
+}
+
+// Index sets the indices to flush. Leave empty to flush all indices.
+func (s *FlushService) Index(indices ...string) *FlushService {
+	s.indices = append(s.indices, indices...)
+	return s
+}
+
+// Do executes the flush request.
+func (s *FlushService) Do() (*Response, error) {
+	var path string
+	if len(s.indices) > 0 {
+		path = fmt.Sprintf("/%s/_flush", strings.Join(s.indices, ","))
+	} else {
+		path = "/_flush"
+	}
+	return s.client.PerformRequest("POST", path, url.Values{}, nil)
+}
@@ -0,0 +1,81 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// IndexService adds or replaces a single document in an index, e.g.:
+//
+//	_, err := client.Index().Index("twitter").Type("tweet").Id("1").BodyJson(&tweet).Do()
+type IndexService struct {
+	client *Client
+	index  string
+	typ    string
+	id     string
+	body   interface{}
+}
+
+// Index creates a new IndexService against the client's cluster.
+func (c *Client) Index() *IndexService {
+	return &IndexService{client: c}
+}
+
+// Index sets the name of the index to index into.
+func (s *IndexService) Index(index string) *IndexService {
+	s.index = index
+	return s
+}
+
+// Type sets the document type.
+func (s *IndexService) Type(typ string) *IndexService {
+	s.typ = typ
+	return s
+}
+
+// Id sets the document id. If empty, Elasticsearch auto-generates one.
+func (s *IndexService) Id(id string) *IndexService {
+	s.id = id
+	return s
+}
+
+// BodyJson sets the document body, marshaled to JSON.
+func (s *IndexService) BodyJson(body interface{}) *IndexService {
+	s.body = body
+	return s
+}
+
+// IndexResult is the response of an IndexService.Do call.
+type IndexResult struct {
+	Index   string `json:"_index"`
+	Type    string `json:"_type"`
+	Id      string `json:"_id"`
+	Version int64  `json:"_version"`
+}
+
+// Do executes the index request.
+func (s *IndexService) Do() (*IndexResult, error) {
+	path := fmt.Sprintf("/%s/%s/%s", s.index, s.typ, s.id)
+
+	method := "PUT"
+	if s.id == "" {
+		method = "POST"
+		path = fmt.Sprintf("/%s/%s", s.index, s.typ)
+	}
+
+	res, err := s.client.PerformRequest(method, path, url.Values{}, s.body)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := new(IndexResult)
+	if err := json.Unmarshal(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,14 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// Query represents the generic query interface. A Query is anything
+// that knows how to render itself into a JSON-serializable value to be
+// used in a query_dsl clause, e.g. MatchAllQuery, TermQuery, RangeQuery,
+// or DateFilter.
+type Query interface {
+	// Source returns the JSON-serializable query.
+	Source() (interface{}, error)
+}
@@ -0,0 +1,33 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// MatchAllQuery matches every document, giving them all an equal
+// _score of 1.0.
+type MatchAllQuery struct {
+	boost *float64
+}
+
+// NewMatchAllQuery creates and initializes a new MatchAllQuery.
+func NewMatchAllQuery() MatchAllQuery {
+	return MatchAllQuery{}
+}
+
+// Boost sets the boost for this query.
+func (q *MatchAllQuery) Boost(boost float64) *MatchAllQuery {
+	q.boost = &boost
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *MatchAllQuery) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["match_all"] = params
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	return source, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// RangeQuery matches documents where a field's value falls within a
+// range of values, e.g.:
+//
+//	q := NewRangeQuery("created").Gte("2012-01-01").Lt("2013-01-01")
+type RangeQuery struct {
+	field        string
+	from         interface{}
+	to           interface{}
+	includeLower bool
+	includeUpper bool
+	boost        *float64
+	queryName    string
+}
+
+// NewRangeQuery creates and initializes a new RangeQuery on field. The
+// range is open (both bounds excluded) until Gte/Gt/Lte/Lt are used.
+func NewRangeQuery(field string) *RangeQuery {
+	return &RangeQuery{field: field}
+}
+
+// Gte sets the lower bound of the range, inclusive.
+func (q *RangeQuery) Gte(from interface{}) *RangeQuery {
+	q.from = from
+	q.includeLower = true
+	return q
+}
+
+// Gt sets the lower bound of the range, exclusive.
+func (q *RangeQuery) Gt(from interface{}) *RangeQuery {
+	q.from = from
+	q.includeLower = false
+	return q
+}
+
+// Lte sets the upper bound of the range, inclusive.
+func (q *RangeQuery) Lte(to interface{}) *RangeQuery {
+	q.to = to
+	q.includeUpper = true
+	return q
+}
+
+// Lt sets the upper bound of the range, exclusive.
+func (q *RangeQuery) Lt(to interface{}) *RangeQuery {
+	q.to = to
+	q.includeUpper = false
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used when
+// searching for matched filters per hit.
+func (q *RangeQuery) QueryName(queryName string) *RangeQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *RangeQuery) Boost(boost float64) *RangeQuery {
+	q.boost = &boost
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *RangeQuery) Source() (interface{}, error) {
+	opts := make(map[string]interface{})
+	if q.from != nil {
+		opts["from"] = q.from
+	}
+	if q.to != nil {
+		opts["to"] = q.to
+	}
+	opts["include_lower"] = q.includeLower
+	opts["include_upper"] = q.includeUpper
+	if q.boost != nil {
+		opts["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		opts["_name"] = q.queryName
+	}
+
+	return map[string]interface{}{
+		"range": map[string]interface{}{
+			q.field: opts,
+		},
+	}, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// TermQuery matches documents containing an exact term in a field,
+// without any analysis.
+type TermQuery struct {
+	field     string
+	value     interface{}
+	boost     *float64
+	queryName string
+}
+
+// NewTermQuery creates and initializes a new TermQuery.
+func NewTermQuery(field string, value interface{}) *TermQuery {
+	return &TermQuery{field: field, value: value}
+}
+
+// QueryName sets the query name for the filter that can be used when
+// searching for matched filters per hit.
+func (q *TermQuery) QueryName(queryName string) *TermQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *TermQuery) Boost(boost float64) *TermQuery {
+	q.boost = &boost
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *TermQuery) Source() (interface{}, error) {
+	if q.boost == nil && q.queryName == "" {
+		return map[string]interface{}{
+			"term": map[string]interface{}{
+				q.field: q.value,
+			},
+		}, nil
+	}
+
+	params := map[string]interface{}{"value": q.value}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return map[string]interface{}{
+		"term": map[string]interface{}{
+			q.field: params,
+		},
+	}, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// TermsSetQuery returns documents that contain a minimum number of exact
+// terms in a provided field. The minimum number of matching terms is
+// given per document, either read from a numeric field named by
+// MinimumShouldMatchField or computed by MinimumShouldMatchScript.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/query-dsl-terms-set-query.html
+type TermsSetQuery struct {
+	field                    string
+	values                   []interface{}
+	minimumShouldMatchField  string
+	minimumShouldMatchScript *Script
+	queryName                string
+	boost                    *float64
+}
+
+// NewTermsSetQuery creates and initializes a new TermsSetQuery.
+func NewTermsSetQuery(field string, values ...interface{}) *TermsSetQuery {
+	return &TermsSetQuery{
+		field:  field,
+		values: append([]interface{}{}, values...),
+	}
+}
+
+// MinimumShouldMatchField specifies the field that holds, per document,
+// the number of terms that must match for that document to be returned.
+func (q *TermsSetQuery) MinimumShouldMatchField(field string) *TermsSetQuery {
+	q.minimumShouldMatchField = field
+	return q
+}
+
+// MinimumShouldMatchScript specifies a script that computes, per
+// document, the number of terms that must match for that document to
+// be returned.
+func (q *TermsSetQuery) MinimumShouldMatchScript(script *Script) *TermsSetQuery {
+	q.minimumShouldMatchScript = script
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used when
+// searching for matched filters per hit.
+func (q *TermsSetQuery) QueryName(queryName string) *TermsSetQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *TermsSetQuery) Boost(boost float64) *TermsSetQuery {
+	q.boost = &boost
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *TermsSetQuery) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["terms_set"] = map[string]interface{}{
+		q.field: params,
+	}
+
+	params["terms"] = q.values
+
+	if q.minimumShouldMatchField != "" {
+		params["minimum_should_match_field"] = q.minimumShouldMatchField
+	}
+	if q.minimumShouldMatchScript != nil {
+		src, err := q.minimumShouldMatchScript.Source()
+		if err != nil {
+			return nil, err
+		}
+		params["minimum_should_match_script"] = src
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+
+	return source, nil
+}
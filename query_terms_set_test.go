@@ -0,0 +1,46 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTermsSetQuery(t *testing.T) {
+	q := NewTermsSetQuery("tags", "red", "blue", "green").
+		MinimumShouldMatchField("required_matches")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"terms_set":{"tags":{"minimum_should_match_field":"required_matches","terms":["red","blue","green"]}}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestTermsSetQueryWithScript(t *testing.T) {
+	q := NewTermsSetQuery("tags", "red", "blue").
+		MinimumShouldMatchScript(NewScript("Math.min(params.num_terms, doc['required_matches'].value)"))
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"terms_set":{"tags":{"minimum_should_match_script":{"source":"Math.min(params.num_terms, doc['required_matches'].value)"},"terms":["red","blue"]}}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
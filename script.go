@@ -0,0 +1,46 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// Script is used in Elasticsearch to evaluate a piece of code, e.g. in
+// scripted fields, script queries, or pipeline aggregations such as
+// BucketScriptAggregation.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/current/modules-scripting.html
+type Script struct {
+	script string
+	lang   string
+	params map[string]interface{}
+}
+
+// NewScript creates and initializes a new Script with inline source.
+func NewScript(script string) *Script {
+	return &Script{script: script}
+}
+
+// Lang sets the scripting language, e.g. "painless" (the default).
+func (s *Script) Lang(lang string) *Script {
+	s.lang = lang
+	return s
+}
+
+// Params sets the named parameters passed to the script.
+func (s *Script) Params(params map[string]interface{}) *Script {
+	s.params = params
+	return s
+}
+
+// Source returns JSON for the script.
+func (s *Script) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	source["source"] = s.script
+	if s.lang != "" {
+		source["lang"] = s.lang
+	}
+	if len(s.params) > 0 {
+		source["params"] = s.params
+	}
+	return source, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SearchService is the entry point for executing a search against one or
+// more indices, e.g.:
+//
+//	all := NewMatchAllQuery()
+//	searchResult, err := client.Search().
+//		Index("twitter").
+//		Query(&all).
+//		Aggregation("users", NewTermsAggregation().Field("user")).
+//		Do()
+type SearchService struct {
+	client  *Client
+	source  *SearchSource
+	indices []string
+	types   []string
+	pretty  bool
+	debug   bool
+}
+
+// NewSearchService creates a new SearchService.
+func NewSearchService(client *Client) *SearchService {
+	return &SearchService{
+		client: client,
+		source: NewSearchSource(),
+	}
+}
+
+// Search creates a new SearchService against the client's cluster.
+func (c *Client) Search() *SearchService {
+	return NewSearchService(c)
+}
+
+// Index sets the indices to search against. Leave empty to search all indices.
+func (s *SearchService) Index(indices ...string) *SearchService {
+	s.indices = append(s.indices, indices...)
+	return s
+}
+
+// Type restricts the search to the given document types.
+func (s *SearchService) Type(types ...string) *SearchService {
+	s.types = append(s.types, types...)
+	return s
+}
+
+// Query sets the query to run, e.g. a MatchAllQuery or a BoolQuery.
+func (s *SearchService) Query(query Query) *SearchService {
+	s.source = s.source.Query(query)
+	return s
+}
+
+// From sets the start offset for pagination.
+func (s *SearchService) From(from int) *SearchService {
+	s.source = s.source.From(from)
+	return s
+}
+
+// Size sets the number of hits to return.
+func (s *SearchService) Size(size int) *SearchService {
+	s.source = s.source.Size(size)
+	return s
+}
+
+// Facet adds a legacy facet to the search request, e.g.
+// Facet("users", NewTermsFacet("user")). Prefer Aggregation for new code.
+func (s *SearchService) Facet(name string, facet Facet) *SearchService {
+	s.source = s.source.Facet(name, facet)
+	return s
+}
+
+// Aggregation adds an aggregation to the search request, e.g.
+// Aggregation("users", NewTermsAggregation().Field("user")). Results are
+// available on the returned SearchResult via its Aggregations field.
+func (s *SearchService) Aggregation(name string, aggregation Aggregation) *SearchService {
+	s.source = s.source.Aggregation(name, aggregation)
+	return s
+}
+
+// Pretty tells Elasticsearch to return formatted JSON responses.
+func (s *SearchService) Pretty(pretty bool) *SearchService {
+	s.pretty = pretty
+	return s
+}
+
+// Debug sets a debug flag on the service. The flag is not sent to
+// Elasticsearch and does not change the request or its handling.
+func (s *SearchService) Debug(debug bool) *SearchService {
+	s.debug = debug
+	return s
+}
+
+// Source returns the JSON-serializable body of the search request.
+func (s *SearchService) Source() (interface{}, error) {
+	return s.source.Source()
+}
+
+// buildURL builds the request path and query parameters for the search.
+func (s *SearchService) buildURL() (string, url.Values, error) {
+	var path string
+	switch {
+	case len(s.indices) > 0 && len(s.types) > 0:
+		path = fmt.Sprintf("/%s/%s/_search", strings.Join(s.indices, ","), strings.Join(s.types, ","))
+	case len(s.indices) > 0:
+		path = fmt.Sprintf("/%s/_search", strings.Join(s.indices, ","))
+	default:
+		path = "/_search"
+	}
+
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+
+	return path, params, nil
+}
+
+// Do executes the search and returns a SearchResult.
+func (s *SearchService) Do() (*SearchResult, error) {
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := s.Source()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.PerformRequest("POST", path, params, body)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := new(SearchResult)
+	if err := json.Unmarshal(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,530 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "encoding/json"
+
+// Aggregation is implemented by all aggregations that can be added to a
+// search request, e.g. via SearchService.Aggregation. Source returns the
+// JSON-serializable representation of the aggregation.
+type Aggregation interface {
+	Source() (interface{}, error)
+}
+
+// Aggregations is returned as part of a SearchResult and gives access to
+// the aggregation results that were requested via SearchService.Aggregation.
+// Since the shape of an aggregation result depends on its type, individual
+// results are decoded lazily through the typed accessors below, e.g.
+// Aggregations.Terms or Aggregations.Avg. Unknown aggregation types can
+// still be accessed through the map itself.
+type Aggregations map[string]*json.RawMessage
+
+// Terms returns terms aggregation results.
+func (a Aggregations) Terms(name string) (*AggregationBucketKeyItems, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationBucketKeyItems)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// Range returns range aggregation results.
+func (a Aggregations) Range(name string) (*AggregationBucketRangeItems, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationBucketRangeItems)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// DateHistogram returns date_histogram aggregation results.
+func (a Aggregations) DateHistogram(name string) (*AggregationBucketHistogramItems, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationBucketHistogramItems)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// Avg returns avg aggregation results.
+func (a Aggregations) Avg(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// Min returns min aggregation results.
+func (a Aggregations) Min(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// Max returns max aggregation results.
+func (a Aggregations) Max(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// Sum returns sum aggregation results.
+func (a Aggregations) Sum(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// ValueCount returns value_count aggregation results.
+func (a Aggregations) ValueCount(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// Cardinality returns cardinality aggregation results.
+func (a Aggregations) Cardinality(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+func (a Aggregations) valueMetric(name string) (*AggregationValueMetric, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationValueMetric)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// Stats returns stats aggregation results.
+func (a Aggregations) Stats(name string) (*AggregationStatsMetric, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationStatsMetric)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// ExtendedStats returns extended_stats aggregation results.
+func (a Aggregations) ExtendedStats(name string) (*AggregationExtendedStatsMetric, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationExtendedStatsMetric)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// Percentiles returns percentiles aggregation results.
+func (a Aggregations) Percentiles(name string) (*AggregationPercentilesMetric, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationPercentilesMetric)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// PercentileRanks returns percentile_ranks aggregation results.
+func (a Aggregations) PercentileRanks(name string) (*AggregationPercentilesMetric, bool) {
+	return a.Percentiles(name)
+}
+
+// SignificantTerms returns significant_terms aggregation results.
+func (a Aggregations) SignificantTerms(name string) (*AggregationSignificantTerms, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationSignificantTerms)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// Sampler returns sampler (or diversified_sampler) aggregation results,
+// a single-bucket aggregation that may itself hold sub-aggregations.
+func (a Aggregations) Sampler(name string) (*AggregationSingleBucket, bool) {
+	return a.Global(name)
+}
+
+// Composite returns composite aggregation results.
+func (a Aggregations) Composite(name string) (*AggregationBucketCompositeItems, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationBucketCompositeItems)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// AvgBucket returns avg_bucket pipeline aggregation results.
+func (a Aggregations) AvgBucket(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// SumBucket returns sum_bucket pipeline aggregation results.
+func (a Aggregations) SumBucket(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// MinBucket returns min_bucket pipeline aggregation results.
+func (a Aggregations) MinBucket(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// MaxBucket returns max_bucket pipeline aggregation results.
+func (a Aggregations) MaxBucket(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// StatsBucket returns stats_bucket pipeline aggregation results.
+func (a Aggregations) StatsBucket(name string) (*AggregationStatsMetric, bool) {
+	return a.Stats(name)
+}
+
+// PercentilesBucket returns percentiles_bucket pipeline aggregation results.
+func (a Aggregations) PercentilesBucket(name string) (*AggregationPercentilesMetric, bool) {
+	return a.Percentiles(name)
+}
+
+// MovAvg returns moving_avg pipeline aggregation results.
+func (a Aggregations) MovAvg(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// CumulativeSum returns cumulative_sum pipeline aggregation results.
+func (a Aggregations) CumulativeSum(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// BucketScript returns bucket_script pipeline aggregation results.
+func (a Aggregations) BucketScript(name string) (*AggregationValueMetric, bool) {
+	return a.valueMetric(name)
+}
+
+// Derivative returns derivative pipeline aggregation results.
+func (a Aggregations) Derivative(name string) (*AggregationDerivativeMetric, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationDerivativeMetric)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// Global returns global aggregation results, a single-bucket aggregation
+// that may itself hold sub-aggregations.
+func (a Aggregations) Global(name string) (*AggregationSingleBucket, bool) {
+	if raw, found := a[name]; found && raw != nil {
+		agg := new(AggregationSingleBucket)
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// Filter returns filter aggregation results, a single-bucket aggregation
+// that may itself hold sub-aggregations.
+func (a Aggregations) Filter(name string) (*AggregationSingleBucket, bool) {
+	return a.Global(name)
+}
+
+// -- Generic result types shared by several aggregations --
+
+// splitAggregationResult splits a raw JSON object into the fields known
+// to the caller (via known) and everything else, which is assumed to be
+// the output of sub-aggregations. It is used by the custom UnmarshalJSON
+// implementations of the various bucket result types below so that
+// bucket.Aggregations.Avg("...") keeps working for nested aggregations.
+func splitAggregationResult(data []byte, known map[string]interface{}) (Aggregations, error) {
+	var raw map[string]*json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	aggs := make(Aggregations)
+	for key, value := range raw {
+		if dest, ok := known[key]; ok {
+			if value == nil {
+				continue
+			}
+			if err := json.Unmarshal(*value, dest); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		aggs[key] = value
+	}
+	return aggs, nil
+}
+
+// AggregationBucketKeyItems is a bucket aggregation that is e.g. returned
+// by a terms aggregation.
+type AggregationBucketKeyItems struct {
+	Aggregations
+
+	DocCountErrorUpperBound int64                       `json:"doc_count_error_upper_bound"`
+	SumOfOtherDocCount      int64                       `json:"sum_other_doc_count"`
+	Buckets                 []*AggregationBucketKeyItem `json:"buckets"`
+}
+
+func (a *AggregationBucketKeyItems) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"doc_count_error_upper_bound": &a.DocCountErrorUpperBound,
+		"sum_other_doc_count":         &a.SumOfOtherDocCount,
+		"buckets":                     &a.Buckets,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationBucketKeyItem is a single bucket of an AggregationBucketKeyItems
+// structure, e.g. a single term of a terms aggregation.
+type AggregationBucketKeyItem struct {
+	Aggregations
+
+	Key         interface{} `json:"key"`
+	KeyAsString *string     `json:"key_as_string"`
+	DocCount    int64       `json:"doc_count"`
+}
+
+func (a *AggregationBucketKeyItem) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"key":           &a.Key,
+		"key_as_string": &a.KeyAsString,
+		"doc_count":     &a.DocCount,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationBucketRangeItems is a bucket aggregation that is e.g. returned
+// by a range aggregation.
+type AggregationBucketRangeItems struct {
+	Aggregations
+
+	Buckets []*AggregationBucketRangeItem `json:"buckets"`
+}
+
+func (a *AggregationBucketRangeItems) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"buckets": &a.Buckets,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationBucketRangeItem is a single bucket of an AggregationBucketRangeItems
+// structure.
+type AggregationBucketRangeItem struct {
+	Aggregations
+
+	Key          string   `json:"key"`
+	DocCount     int64    `json:"doc_count"`
+	From         *float64 `json:"from"`
+	FromAsString string   `json:"from_as_string"`
+	To           *float64 `json:"to"`
+	ToAsString   string   `json:"to_as_string"`
+}
+
+func (a *AggregationBucketRangeItem) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"key":            &a.Key,
+		"doc_count":      &a.DocCount,
+		"from":           &a.From,
+		"from_as_string": &a.FromAsString,
+		"to":             &a.To,
+		"to_as_string":   &a.ToAsString,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationBucketHistogramItems is a bucket aggregation that is e.g.
+// returned by a date_histogram aggregation.
+type AggregationBucketHistogramItems struct {
+	Aggregations
+
+	Buckets []*AggregationBucketHistogramItem `json:"buckets"`
+}
+
+func (a *AggregationBucketHistogramItems) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"buckets": &a.Buckets,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationBucketHistogramItem is a single bucket of an
+// AggregationBucketHistogramItems structure.
+type AggregationBucketHistogramItem struct {
+	Aggregations
+
+	Key         int64  `json:"key"`
+	KeyAsString string `json:"key_as_string"`
+	DocCount    int64  `json:"doc_count"`
+}
+
+func (a *AggregationBucketHistogramItem) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"key":           &a.Key,
+		"key_as_string": &a.KeyAsString,
+		"doc_count":     &a.DocCount,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationBucketCompositeItems is a bucket aggregation that is
+// returned by a composite aggregation. AfterKey, when non-nil, should
+// be passed to CompositeAggregation.AggregateAfter on the next request
+// in order to retrieve the following page of buckets; an empty Buckets
+// slice signals that there are no more pages.
+type AggregationBucketCompositeItems struct {
+	Aggregations
+
+	AfterKey map[string]interface{}     `json:"after_key"`
+	Buckets  []*AggregationBucketKeyItem `json:"buckets"`
+}
+
+func (a *AggregationBucketCompositeItems) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"after_key": &a.AfterKey,
+		"buckets":   &a.Buckets,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationSignificantTerms is returned by a significant_terms
+// aggregation.
+type AggregationSignificantTerms struct {
+	Aggregations
+
+	DocCount int64                     `json:"doc_count"`
+	Buckets  []*SignificantTermsBucket `json:"buckets"`
+}
+
+func (a *AggregationSignificantTerms) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"doc_count": &a.DocCount,
+		"buckets":   &a.Buckets,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// SignificantTermsBucket is a single bucket of an
+// AggregationSignificantTerms structure.
+type SignificantTermsBucket struct {
+	Aggregations
+
+	Key      interface{} `json:"key"`
+	DocCount int64       `json:"doc_count"`
+	BgCount  int64       `json:"bg_count"`
+	Score    float64     `json:"score"`
+}
+
+func (a *SignificantTermsBucket) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"key":       &a.Key,
+		"doc_count": &a.DocCount,
+		"bg_count":  &a.BgCount,
+		"score":     &a.Score,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationSingleBucket is a single-bucket aggregation, e.g. global
+// or filter, which carries a doc count and may itself hold further
+// sub-aggregations.
+type AggregationSingleBucket struct {
+	Aggregations
+
+	DocCount int64 `json:"doc_count"`
+}
+
+func (a *AggregationSingleBucket) UnmarshalJSON(data []byte) error {
+	aggs, err := splitAggregationResult(data, map[string]interface{}{
+		"doc_count": &a.DocCount,
+	})
+	if err != nil {
+		return err
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationValueMetric is a single-value metric, e.g. returned by a
+// avg, min, max, sum, value_count or cardinality aggregation.
+type AggregationValueMetric struct {
+	Value *float64 `json:"value"`
+}
+
+// AggregationStatsMetric is returned by a stats aggregation.
+type AggregationStatsMetric struct {
+	Count float64  `json:"count"`
+	Min   *float64 `json:"min"`
+	Max   *float64 `json:"max"`
+	Avg   *float64 `json:"avg"`
+	Sum   *float64 `json:"sum"`
+}
+
+// AggregationExtendedStatsMetric is returned by an extended_stats aggregation.
+type AggregationExtendedStatsMetric struct {
+	Count        float64  `json:"count"`
+	Min          *float64 `json:"min"`
+	Max          *float64 `json:"max"`
+	Avg          *float64 `json:"avg"`
+	Sum          *float64 `json:"sum"`
+	SumOfSquares *float64 `json:"sum_of_squares"`
+	Variance     *float64 `json:"variance"`
+	StdDeviation *float64 `json:"std_deviation"`
+}
+
+// AggregationPercentilesMetric is returned by a percentiles or
+// percentile_ranks aggregation.
+type AggregationPercentilesMetric struct {
+	Values map[string]float64 `json:"values"`
+}
+
+// AggregationDerivativeMetric is returned by a derivative pipeline
+// aggregation. NormalizedValue is only set when Unit was specified on
+// the DerivativeAggregation.
+type AggregationDerivativeMetric struct {
+	Value           *float64 `json:"value"`
+	NormalizedValue *float64 `json:"normalized_value"`
+}
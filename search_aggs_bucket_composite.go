@@ -0,0 +1,335 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// CompositeAggregation is a multi-bucket aggregation that creates
+// composite buckets from different sources, in a similar fashion to
+// how a SQL GROUP BY would work on several columns. Its advantage over
+// a plain terms aggregation is that it lets the caller page through
+// every bucket deterministically via AggregateAfter, without the
+// accuracy problems of a terms aggregation with a very large size.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-composite-aggregation.html
+type CompositeAggregation struct {
+	sources         []CompositeAggregationValuesSource
+	size            *int
+	after           map[string]interface{}
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewCompositeAggregation creates a new CompositeAggregation.
+func NewCompositeAggregation() *CompositeAggregation {
+	return &CompositeAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Sources sets the value sources that make up the composite key, in order.
+func (a *CompositeAggregation) Sources(sources ...CompositeAggregationValuesSource) *CompositeAggregation {
+	a.sources = append(a.sources, sources...)
+	return a
+}
+
+// Size sets the number of composite buckets to return per request.
+func (a *CompositeAggregation) Size(size int) *CompositeAggregation {
+	a.size = &size
+	return a
+}
+
+// AggregateAfter sets the after-key to resume pagination from. It should
+// be set to the AfterKey of the AggregationBucketCompositeItems returned
+// by the previous request in order to retrieve the next page of buckets.
+func (a *CompositeAggregation) AggregateAfter(after map[string]interface{}) *CompositeAggregation {
+	a.after = after
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *CompositeAggregation) SubAggregation(name string, subAggregation Aggregation) *CompositeAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *CompositeAggregation) Meta(metaData map[string]interface{}) *CompositeAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *CompositeAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["composite"] = opts
+
+	sources := make([]interface{}, 0, len(a.sources))
+	for _, s := range a.sources {
+		src, err := s.Source()
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, map[string]interface{}{s.Name(): src})
+	}
+	opts["sources"] = sources
+
+	if a.size != nil {
+		opts["size"] = *a.size
+	}
+	if len(a.after) > 0 {
+		opts["after"] = a.after
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
+
+// CompositeAggregationValuesSource is implemented by the individual
+// value sources (terms, histogram, date_histogram) that make up a
+// CompositeAggregation's composite key.
+type CompositeAggregationValuesSource interface {
+	Name() string
+	Source() (interface{}, error)
+}
+
+// -- Terms values source --
+
+// CompositeAggregationTermsValuesSource is a source for a
+// CompositeAggregation that is built from a terms-like value source.
+type CompositeAggregationTermsValuesSource struct {
+	name   string
+	field  string
+	script *Script
+	order  string
+}
+
+// NewCompositeAggregationTermsValuesSource creates a new
+// CompositeAggregationTermsValuesSource with the given name, which is
+// also used as the bucket key in the composite aggregation result.
+func NewCompositeAggregationTermsValuesSource(name string) *CompositeAggregationTermsValuesSource {
+	return &CompositeAggregationTermsValuesSource{name: name, order: "asc"}
+}
+
+// Name returns the name of the values source, used as a key in the
+// composite bucket's key and after-key.
+func (a *CompositeAggregationTermsValuesSource) Name() string {
+	return a.name
+}
+
+// Field on which the values source is computed.
+func (a *CompositeAggregationTermsValuesSource) Field(field string) *CompositeAggregationTermsValuesSource {
+	a.field = field
+	return a
+}
+
+// Script computes the value the values source operates on.
+func (a *CompositeAggregationTermsValuesSource) Script(script *Script) *CompositeAggregationTermsValuesSource {
+	a.script = script
+	return a
+}
+
+// Order sets the sort order of this values source, either "asc" or "desc".
+func (a *CompositeAggregationTermsValuesSource) Order(order string) *CompositeAggregationTermsValuesSource {
+	a.order = order
+	return a
+}
+
+// Source returns the serializable JSON for this values source.
+func (a *CompositeAggregationTermsValuesSource) Source() (interface{}, error) {
+	opts := make(map[string]interface{})
+	src := make(map[string]interface{})
+	src["terms"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		s, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = s
+	}
+	if a.order != "" {
+		opts["order"] = a.order
+	}
+
+	return src, nil
+}
+
+// -- Histogram values source --
+
+// CompositeAggregationHistogramValuesSource is a source for a
+// CompositeAggregation that is built from a histogram-like value source.
+type CompositeAggregationHistogramValuesSource struct {
+	name     string
+	field    string
+	script   *Script
+	interval float64
+	order    string
+}
+
+// NewCompositeAggregationHistogramValuesSource creates a new
+// CompositeAggregationHistogramValuesSource with the given name and
+// interval.
+func NewCompositeAggregationHistogramValuesSource(name string, interval float64) *CompositeAggregationHistogramValuesSource {
+	return &CompositeAggregationHistogramValuesSource{name: name, interval: interval, order: "asc"}
+}
+
+// Name returns the name of the values source.
+func (a *CompositeAggregationHistogramValuesSource) Name() string {
+	return a.name
+}
+
+// Field on which the values source is computed.
+func (a *CompositeAggregationHistogramValuesSource) Field(field string) *CompositeAggregationHistogramValuesSource {
+	a.field = field
+	return a
+}
+
+// Script computes the value the values source operates on.
+func (a *CompositeAggregationHistogramValuesSource) Script(script *Script) *CompositeAggregationHistogramValuesSource {
+	a.script = script
+	return a
+}
+
+// Order sets the sort order of this values source, either "asc" or "desc".
+func (a *CompositeAggregationHistogramValuesSource) Order(order string) *CompositeAggregationHistogramValuesSource {
+	a.order = order
+	return a
+}
+
+// Source returns the serializable JSON for this values source.
+func (a *CompositeAggregationHistogramValuesSource) Source() (interface{}, error) {
+	opts := make(map[string]interface{})
+	src := make(map[string]interface{})
+	src["histogram"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		s, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = s
+	}
+	opts["interval"] = a.interval
+	if a.order != "" {
+		opts["order"] = a.order
+	}
+
+	return src, nil
+}
+
+// -- Date histogram values source --
+
+// CompositeAggregationDateHistogramValuesSource is a source for a
+// CompositeAggregation that is built from a date_histogram-like value
+// source.
+type CompositeAggregationDateHistogramValuesSource struct {
+	name     string
+	field    string
+	script   *Script
+	interval string
+	format   string
+	timeZone string
+	order    string
+}
+
+// NewCompositeAggregationDateHistogramValuesSource creates a new
+// CompositeAggregationDateHistogramValuesSource with the given name.
+func NewCompositeAggregationDateHistogramValuesSource(name string) *CompositeAggregationDateHistogramValuesSource {
+	return &CompositeAggregationDateHistogramValuesSource{name: name, order: "asc"}
+}
+
+// Name returns the name of the values source.
+func (a *CompositeAggregationDateHistogramValuesSource) Name() string {
+	return a.name
+}
+
+// Field on which the values source is computed.
+func (a *CompositeAggregationDateHistogramValuesSource) Field(field string) *CompositeAggregationDateHistogramValuesSource {
+	a.field = field
+	return a
+}
+
+// Script computes the value the values source operates on.
+func (a *CompositeAggregationDateHistogramValuesSource) Script(script *Script) *CompositeAggregationDateHistogramValuesSource {
+	a.script = script
+	return a
+}
+
+// Interval sets the date interval, e.g. "1d" or "1M".
+func (a *CompositeAggregationDateHistogramValuesSource) Interval(interval string) *CompositeAggregationDateHistogramValuesSource {
+	a.interval = interval
+	return a
+}
+
+// Format sets the format to apply to the returned bucket keys.
+func (a *CompositeAggregationDateHistogramValuesSource) Format(format string) *CompositeAggregationDateHistogramValuesSource {
+	a.format = format
+	return a
+}
+
+// TimeZone sets the time zone to use when bucketing.
+func (a *CompositeAggregationDateHistogramValuesSource) TimeZone(timeZone string) *CompositeAggregationDateHistogramValuesSource {
+	a.timeZone = timeZone
+	return a
+}
+
+// Order sets the sort order of this values source, either "asc" or "desc".
+func (a *CompositeAggregationDateHistogramValuesSource) Order(order string) *CompositeAggregationDateHistogramValuesSource {
+	a.order = order
+	return a
+}
+
+// Source returns the serializable JSON for this values source.
+func (a *CompositeAggregationDateHistogramValuesSource) Source() (interface{}, error) {
+	opts := make(map[string]interface{})
+	src := make(map[string]interface{})
+	src["date_histogram"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		s, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = s
+	}
+	if a.interval != "" {
+		opts["interval"] = a.interval
+	}
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.timeZone != "" {
+		opts["time_zone"] = a.timeZone
+	}
+	if a.order != "" {
+		opts["order"] = a.order
+	}
+
+	return src, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAggsCompositeSource(t *testing.T) {
+	agg := NewCompositeAggregation().
+		Sources(
+			NewCompositeAggregationTermsValuesSource("user").Field("user").Order("asc"),
+			NewCompositeAggregationHistogramValuesSource("retweets", 10.0),
+			NewCompositeAggregationDateHistogramValuesSource("created").Interval("1d"),
+		).
+		Size(100).
+		AggregateAfter(map[string]interface{}{"user": "olivere"})
+
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"composite":{"after":{"user":"olivere"},"size":100,"sources":[{"user":{"terms":{"field":"user","order":"asc"}}},{"retweets":{"histogram":{"interval":10,"order":"asc"}}},{"created":{"date_histogram":{"interval":"1d","order":"asc"}}}]}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAggregationsCompositeUnmarshal(t *testing.T) {
+	raw := `{
+		"users_per_day" : {
+			"buckets" : [
+				{ "key" : { "user" : "olivere", "created" : 1355270400000 }, "doc_count" : 2 }
+			],
+			"after_key" : { "user" : "olivere", "created" : 1355270400000 }
+		}
+	}`
+
+	aggs := make(Aggregations)
+	if err := json.Unmarshal([]byte(raw), &aggs); err != nil {
+		t.Fatal(err)
+	}
+
+	composite, found := aggs.Composite("users_per_day")
+	if !found {
+		t.Fatalf("expected to find composite aggregation %q", "users_per_day")
+	}
+	if len(composite.Buckets) != 1 {
+		t.Fatalf("expected 1 bucket, got %d", len(composite.Buckets))
+	}
+	if composite.AfterKey["user"] != "olivere" {
+		t.Errorf("expected after_key.user = %q, got %v", "olivere", composite.AfterKey["user"])
+	}
+
+	if _, found := aggs.Composite("no-such-aggregation"); found {
+		t.Errorf("expected no-such-aggregation to not be found")
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// DateHistogramAggregation is a multi-bucket aggregation similar to a
+// histogram, but it can only be applied on date values. Since dates
+// are represented in Elasticsearch internally as long values, it is
+// possible to use the normal histogram on dates as well, though
+// accuracy would be compromised due to the fact that time based
+// intervals are not fixed (think of leap years and on the number of
+// days in a month).
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-datehistogram-aggregation.html
+type DateHistogramAggregation struct {
+	field           string
+	script          *Script
+	interval        string
+	format          string
+	timeZone        string
+	minDocCount     *int64
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewDateHistogramAggregation creates a new DateHistogramAggregation.
+func NewDateHistogramAggregation() *DateHistogramAggregation {
+	return &DateHistogramAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *DateHistogramAggregation) Field(field string) *DateHistogramAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the value the aggregation operates on.
+func (a *DateHistogramAggregation) Script(script *Script) *DateHistogramAggregation {
+	a.script = script
+	return a
+}
+
+// Interval sets the interval for the buckets, e.g. "year", "month",
+// "day", "hour" or a fixed interval like "1.5h".
+func (a *DateHistogramAggregation) Interval(interval string) *DateHistogramAggregation {
+	a.interval = interval
+	return a
+}
+
+// Format sets the format to apply to the returned bucket keys.
+func (a *DateHistogramAggregation) Format(format string) *DateHistogramAggregation {
+	a.format = format
+	return a
+}
+
+// TimeZone sets the time zone, e.g. "-01:00", to use when bucketing.
+func (a *DateHistogramAggregation) TimeZone(timeZone string) *DateHistogramAggregation {
+	a.timeZone = timeZone
+	return a
+}
+
+// MinDocCount sets the minimum document count per bucket. Buckets with
+// fewer documents than this are filtered out.
+func (a *DateHistogramAggregation) MinDocCount(minDocCount int64) *DateHistogramAggregation {
+	a.minDocCount = &minDocCount
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *DateHistogramAggregation) SubAggregation(name string, subAggregation Aggregation) *DateHistogramAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *DateHistogramAggregation) Meta(metaData map[string]interface{}) *DateHistogramAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *DateHistogramAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["date_histogram"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.interval != "" {
+		opts["interval"] = a.interval
+	}
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.timeZone != "" {
+		opts["time_zone"] = a.timeZone
+	}
+	if a.minDocCount != nil {
+		opts["min_doc_count"] = *a.minDocCount
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
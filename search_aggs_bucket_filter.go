@@ -0,0 +1,66 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// FilterAggregation defines a single bucket of all the documents in the
+// current document set context that match a specified filter. Often
+// this will be used to narrow down the current aggregation context to
+// a specific set of documents.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-filter-aggregation.html
+type FilterAggregation struct {
+	filter          Query
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewFilterAggregation creates a new FilterAggregation.
+func NewFilterAggregation(filter Query) *FilterAggregation {
+	return &FilterAggregation{
+		filter:          filter,
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *FilterAggregation) SubAggregation(name string, subAggregation Aggregation) *FilterAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *FilterAggregation) Meta(metaData map[string]interface{}) *FilterAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *FilterAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+
+	src, err := a.filter.Source()
+	if err != nil {
+		return nil, err
+	}
+	source["filter"] = src
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// GlobalAggregation defines a single bucket of all the documents within
+// the search execution context. This context is defined by the indices
+// and the document types you're searching on, but is not influenced by
+// the search query itself. It is a way to break out of the aggregation
+// context so that sibling aggregations run against the whole index,
+// e.g. to compare a filtered subset's stats against the global average.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-global-aggregation.html
+type GlobalAggregation struct {
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewGlobalAggregation creates a new GlobalAggregation.
+func NewGlobalAggregation() *GlobalAggregation {
+	return &GlobalAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *GlobalAggregation) SubAggregation(name string, subAggregation Aggregation) *GlobalAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *GlobalAggregation) Meta(metaData map[string]interface{}) *GlobalAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *GlobalAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	source["global"] = struct{}{}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// RangeAggregation is a multi-bucket value source based aggregation that
+// lets the user define a set of ranges - each representing a bucket.
+// During the aggregation process, the values extracted from each document
+// are checked against each bucket range and "bucketed" accordingly.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-range-aggregation.html
+type RangeAggregation struct {
+	field           string
+	script          *Script
+	keyed           *bool
+	entries         []rangeAggregationEntry
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+type rangeAggregationEntry struct {
+	Key  string
+	From interface{}
+	To   interface{}
+}
+
+// NewRangeAggregation creates a new RangeAggregation.
+func NewRangeAggregation() *RangeAggregation {
+	return &RangeAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *RangeAggregation) Field(field string) *RangeAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the value the aggregation operates on.
+func (a *RangeAggregation) Script(script *Script) *RangeAggregation {
+	a.script = script
+	return a
+}
+
+// Keyed specifies whether to associate a unique string key with each
+// bucket and return the ranges as a hash rather than an array.
+func (a *RangeAggregation) Keyed(keyed bool) *RangeAggregation {
+	a.keyed = &keyed
+	return a
+}
+
+// Lt adds a range with no lower bound and an upper bound of to.
+func (a *RangeAggregation) Lt(to float64) *RangeAggregation {
+	a.entries = append(a.entries, rangeAggregationEntry{From: nil, To: to})
+	return a
+}
+
+// Between adds a range bounded by from and to.
+func (a *RangeAggregation) Between(from, to float64) *RangeAggregation {
+	a.entries = append(a.entries, rangeAggregationEntry{From: from, To: to})
+	return a
+}
+
+// Gt adds a range with a lower bound of from and no upper bound.
+func (a *RangeAggregation) Gt(from float64) *RangeAggregation {
+	a.entries = append(a.entries, rangeAggregationEntry{From: from, To: nil})
+	return a
+}
+
+// AddRange adds a range with an optional key, from and to.
+func (a *RangeAggregation) AddRange(key string, from, to interface{}) *RangeAggregation {
+	a.entries = append(a.entries, rangeAggregationEntry{Key: key, From: from, To: to})
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *RangeAggregation) SubAggregation(name string, subAggregation Aggregation) *RangeAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *RangeAggregation) Meta(metaData map[string]interface{}) *RangeAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *RangeAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["range"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.keyed != nil {
+		opts["keyed"] = *a.keyed
+	}
+
+	ranges := make([]interface{}, 0, len(a.entries))
+	for _, entry := range a.entries {
+		r := make(map[string]interface{})
+		if entry.Key != "" {
+			r["key"] = entry.Key
+		}
+		if entry.From != nil {
+			r["from"] = entry.From
+		}
+		if entry.To != nil {
+			r["to"] = entry.To
+		}
+		ranges = append(ranges, r)
+	}
+	opts["ranges"] = ranges
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
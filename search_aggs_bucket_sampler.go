@@ -0,0 +1,183 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SamplerAggregation is a filtering aggregation that limits the number
+// of documents on which sub-aggregations run, per shard, in order to
+// make expensive sub-aggregations (e.g. SignificantTermsAggregation)
+// affordable on large result sets.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-sampler-aggregation.html
+type SamplerAggregation struct {
+	shardSize       *int
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewSamplerAggregation creates a new SamplerAggregation.
+func NewSamplerAggregation() *SamplerAggregation {
+	return &SamplerAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// ShardSize sets the maximum number of documents collected on each
+// shard that sub-aggregations will run against.
+func (a *SamplerAggregation) ShardSize(shardSize int) *SamplerAggregation {
+	a.shardSize = &shardSize
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *SamplerAggregation) SubAggregation(name string, subAggregation Aggregation) *SamplerAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *SamplerAggregation) Meta(metaData map[string]interface{}) *SamplerAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *SamplerAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["sampler"] = opts
+
+	if a.shardSize != nil {
+		opts["shard_size"] = *a.shardSize
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
+
+// DiversifiedSamplerAggregation is like SamplerAggregation, but also
+// caps the number of documents collected per unique value of a chosen
+// field, e.g. to avoid a single prolific author dominating a sample.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-diversified-sampler-aggregation.html
+type DiversifiedSamplerAggregation struct {
+	field           string
+	script          *Script
+	shardSize       *int
+	maxDocsPerValue *int
+	executionHint   string
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewDiversifiedSamplerAggregation creates a new DiversifiedSamplerAggregation.
+func NewDiversifiedSamplerAggregation() *DiversifiedSamplerAggregation {
+	return &DiversifiedSamplerAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field whose value is used to diversify the sample.
+func (a *DiversifiedSamplerAggregation) Field(field string) *DiversifiedSamplerAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the value used to diversify the sample.
+func (a *DiversifiedSamplerAggregation) Script(script *Script) *DiversifiedSamplerAggregation {
+	a.script = script
+	return a
+}
+
+// ShardSize sets the maximum number of documents collected on each shard.
+func (a *DiversifiedSamplerAggregation) ShardSize(shardSize int) *DiversifiedSamplerAggregation {
+	a.shardSize = &shardSize
+	return a
+}
+
+// MaxDocsPerValue sets the maximum number of documents collected per
+// value of Field.
+func (a *DiversifiedSamplerAggregation) MaxDocsPerValue(maxDocsPerValue int) *DiversifiedSamplerAggregation {
+	a.maxDocsPerValue = &maxDocsPerValue
+	return a
+}
+
+// ExecutionHint sets the mechanism used to deduplicate values, e.g.
+// "map", "global_ordinals" or "bytes_hash".
+func (a *DiversifiedSamplerAggregation) ExecutionHint(hint string) *DiversifiedSamplerAggregation {
+	a.executionHint = hint
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *DiversifiedSamplerAggregation) SubAggregation(name string, subAggregation Aggregation) *DiversifiedSamplerAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *DiversifiedSamplerAggregation) Meta(metaData map[string]interface{}) *DiversifiedSamplerAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *DiversifiedSamplerAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["diversified_sampler"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.shardSize != nil {
+		opts["shard_size"] = *a.shardSize
+	}
+	if a.maxDocsPerValue != nil {
+		opts["max_docs_per_value"] = *a.maxDocsPerValue
+	}
+	if a.executionHint != "" {
+		opts["execution_hint"] = a.executionHint
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SignificantTermsAggregation returns the collection of terms that are
+// statistically significant in the context of a foreground set of
+// documents compared against a background set, e.g. to surface terms
+// that are unusually common in a subset of a corpus. Significance is
+// expressed via a SignificanceHeuristic, e.g. NewJLHScoreSignificanceHeuristic,
+// NewMutualInformationSignificanceHeuristic, NewChiSquareSignificanceHeuristic,
+// NewGNDSignificanceHeuristic or NewPercentageScoreSignificanceHeuristic.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-significantterms-aggregation.html
+type SignificantTermsAggregation struct {
+	field            string
+	size             *int
+	shardSize        *int
+	minDocCount      *int64
+	shardMinDocCount *int64
+	backgroundFilter Query
+	heuristic        SignificanceHeuristic
+	includePattern   string
+	excludePattern   string
+	executionHint    string
+	subAggregations  map[string]Aggregation
+	meta             map[string]interface{}
+}
+
+// NewSignificantTermsAggregation creates a new SignificantTermsAggregation.
+func NewSignificantTermsAggregation() *SignificantTermsAggregation {
+	return &SignificantTermsAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *SignificantTermsAggregation) Field(field string) *SignificantTermsAggregation {
+	a.field = field
+	return a
+}
+
+// Size sets the number of term buckets that should be returned.
+func (a *SignificantTermsAggregation) Size(size int) *SignificantTermsAggregation {
+	a.size = &size
+	return a
+}
+
+// ShardSize sets the number of terms each shard returns to the
+// coordinating node before final merge.
+func (a *SignificantTermsAggregation) ShardSize(shardSize int) *SignificantTermsAggregation {
+	a.shardSize = &shardSize
+	return a
+}
+
+// MinDocCount sets the minimum document count a term must have, in the
+// foreground set, to be returned as a bucket.
+func (a *SignificantTermsAggregation) MinDocCount(minDocCount int64) *SignificantTermsAggregation {
+	a.minDocCount = &minDocCount
+	return a
+}
+
+// ShardMinDocCount sets the minimum document count a term must have on
+// an individual shard before being returned for the final merge.
+func (a *SignificantTermsAggregation) ShardMinDocCount(shardMinDocCount int64) *SignificantTermsAggregation {
+	a.shardMinDocCount = &shardMinDocCount
+	return a
+}
+
+// BackgroundFilter sets a query used to narrow the background set of
+// documents that terms are compared against. Without it, the background
+// set defaults to the whole index.
+func (a *SignificantTermsAggregation) BackgroundFilter(filter Query) *SignificantTermsAggregation {
+	a.backgroundFilter = filter
+	return a
+}
+
+// SignificanceHeuristic sets the algorithm used to score each term,
+// e.g. JLH, mutual_information, chi_square, gnd or percentage.
+func (a *SignificantTermsAggregation) SignificanceHeuristic(heuristic SignificanceHeuristic) *SignificantTermsAggregation {
+	a.heuristic = heuristic
+	return a
+}
+
+// Include filters terms that match the given regular expression.
+func (a *SignificantTermsAggregation) Include(regexp string) *SignificantTermsAggregation {
+	a.includePattern = regexp
+	return a
+}
+
+// Exclude filters out terms that match the given regular expression.
+func (a *SignificantTermsAggregation) Exclude(regexp string) *SignificantTermsAggregation {
+	a.excludePattern = regexp
+	return a
+}
+
+// ExecutionHint sets the mechanism used to collect terms, e.g. "map" or
+// "global_ordinals".
+func (a *SignificantTermsAggregation) ExecutionHint(hint string) *SignificantTermsAggregation {
+	a.executionHint = hint
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *SignificantTermsAggregation) SubAggregation(name string, subAggregation Aggregation) *SignificantTermsAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *SignificantTermsAggregation) Meta(metaData map[string]interface{}) *SignificantTermsAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *SignificantTermsAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["significant_terms"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.size != nil {
+		opts["size"] = *a.size
+	}
+	if a.shardSize != nil {
+		opts["shard_size"] = *a.shardSize
+	}
+	if a.minDocCount != nil {
+		opts["min_doc_count"] = *a.minDocCount
+	}
+	if a.shardMinDocCount != nil {
+		opts["shard_min_doc_count"] = *a.shardMinDocCount
+	}
+	if a.includePattern != "" {
+		opts["include"] = a.includePattern
+	}
+	if a.excludePattern != "" {
+		opts["exclude"] = a.excludePattern
+	}
+	if a.executionHint != "" {
+		opts["execution_hint"] = a.executionHint
+	}
+	if a.backgroundFilter != nil {
+		src, err := a.backgroundFilter.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["background_filter"] = src
+	}
+	if a.heuristic != nil {
+		name, src, err := a.heuristic.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts[name] = src
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
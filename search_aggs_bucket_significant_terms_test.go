@@ -0,0 +1,96 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAggsSignificantTermsSource(t *testing.T) {
+	agg := NewSignificantTermsAggregation().
+		Field("message").
+		MinDocCount(3).
+		SignificanceHeuristic(NewChiSquareSignificanceHeuristic().BackgroundIsSuperset(false).IncludeNegatives(true))
+
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"significant_terms":{"chi_square":{"background_is_superset":false,"include_negatives":true},"field":"message","min_doc_count":3}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAggsSamplerSource(t *testing.T) {
+	sigTerms := NewSignificantTermsAggregation().Field("message")
+	agg := NewSamplerAggregation().ShardSize(200).SubAggregation("interesting_terms", sigTerms)
+
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"interesting_terms":{"significant_terms":{"field":"message"}}},"sampler":{"shard_size":200}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAggsDiversifiedSamplerSource(t *testing.T) {
+	agg := NewDiversifiedSamplerAggregation().Field("user").MaxDocsPerValue(3)
+
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"diversified_sampler":{"field":"user","max_docs_per_value":3}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAggregationsSignificantTermsUnmarshal(t *testing.T) {
+	raw := `{
+		"interesting_terms" : {
+			"doc_count" : 100,
+			"buckets" : [
+				{ "key" : "error", "doc_count" : 10, "bg_count" : 20, "score" : 1.5 }
+			]
+		}
+	}`
+
+	aggs := make(Aggregations)
+	if err := json.Unmarshal([]byte(raw), &aggs); err != nil {
+		t.Fatal(err)
+	}
+
+	sigTerms, found := aggs.SignificantTerms("interesting_terms")
+	if !found {
+		t.Fatalf("expected to find significant_terms aggregation %q", "interesting_terms")
+	}
+	if len(sigTerms.Buckets) != 1 {
+		t.Fatalf("expected 1 bucket, got %d", len(sigTerms.Buckets))
+	}
+	b := sigTerms.Buckets[0]
+	if b.Key != "error" || b.DocCount != 10 || b.BgCount != 20 || b.Score != 1.5 {
+		t.Errorf("unexpected bucket contents: %+v", b)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// TermsAggregation is a multi-bucket value source based aggregation
+// where buckets are dynamically built - one per unique value.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-terms-aggregation.html
+type TermsAggregation struct {
+	field           string
+	script          *Script
+	size            *int
+	shardSize       *int
+	minDocCount     *int64
+	includePattern  string
+	excludePattern  string
+	order           string
+	orderAsc        bool
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewTermsAggregation creates a new TermsAggregation.
+func NewTermsAggregation() *TermsAggregation {
+	return &TermsAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *TermsAggregation) Field(field string) *TermsAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the terms the aggregation operates on.
+func (a *TermsAggregation) Script(script *Script) *TermsAggregation {
+	a.script = script
+	return a
+}
+
+// Size sets the number of term buckets that should be returned.
+func (a *TermsAggregation) Size(size int) *TermsAggregation {
+	a.size = &size
+	return a
+}
+
+// ShardSize sets the number of terms each shard returns to the
+// coordinating node before final merge, to improve accuracy.
+func (a *TermsAggregation) ShardSize(shardSize int) *TermsAggregation {
+	a.shardSize = &shardSize
+	return a
+}
+
+// MinDocCount sets the minimum document count a term must have to be
+// returned as a bucket.
+func (a *TermsAggregation) MinDocCount(minDocCount int64) *TermsAggregation {
+	a.minDocCount = &minDocCount
+	return a
+}
+
+// Include filters terms that match the given regular expression.
+func (a *TermsAggregation) Include(regexp string) *TermsAggregation {
+	a.includePattern = regexp
+	return a
+}
+
+// Exclude filters out terms that match the given regular expression.
+func (a *TermsAggregation) Exclude(regexp string) *TermsAggregation {
+	a.excludePattern = regexp
+	return a
+}
+
+// Order sets the order in which the buckets are returned, e.g. "_count"
+// or "_term".
+func (a *TermsAggregation) Order(order string, ascending bool) *TermsAggregation {
+	a.order = order
+	a.orderAsc = ascending
+	return a
+}
+
+// OrderByCountAsc orders the buckets by their doc count ascending.
+func (a *TermsAggregation) OrderByCountAsc() *TermsAggregation {
+	return a.Order("_count", true)
+}
+
+// OrderByCountDesc orders the buckets by their doc count descending.
+// This is the default order used by Elasticsearch itself.
+func (a *TermsAggregation) OrderByCountDesc() *TermsAggregation {
+	return a.Order("_count", false)
+}
+
+// OrderByTermAsc orders the buckets alphabetically by their term ascending.
+func (a *TermsAggregation) OrderByTermAsc() *TermsAggregation {
+	return a.Order("_term", true)
+}
+
+// OrderByTermDesc orders the buckets alphabetically by their term descending.
+func (a *TermsAggregation) OrderByTermDesc() *TermsAggregation {
+	return a.Order("_term", false)
+}
+
+// OrderByAggregation orders the buckets by a sub-aggregation, e.g.
+// OrderByAggregation("avg_price", false) to sort by the "avg_price"
+// sub-aggregation descending.
+func (a *TermsAggregation) OrderByAggregation(aggName string, ascending bool) *TermsAggregation {
+	return a.Order(aggName, ascending)
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *TermsAggregation) SubAggregation(name string, subAggregation Aggregation) *TermsAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *TermsAggregation) Meta(metaData map[string]interface{}) *TermsAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *TermsAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["terms"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.size != nil {
+		opts["size"] = *a.size
+	}
+	if a.shardSize != nil {
+		opts["shard_size"] = *a.shardSize
+	}
+	if a.minDocCount != nil {
+		opts["min_doc_count"] = *a.minDocCount
+	}
+	if a.includePattern != "" {
+		opts["include"] = a.includePattern
+	}
+	if a.excludePattern != "" {
+		opts["exclude"] = a.excludePattern
+	}
+	if a.order != "" {
+		o := make(map[string]interface{})
+		if a.orderAsc {
+			o[a.order] = "asc"
+		} else {
+			o[a.order] = "desc"
+		}
+		opts["order"] = o
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// CardinalityAggregation is a single-value metrics aggregation that
+// calculates an approximate count of distinct values extracted from
+// the aggregated documents. Values can be extracted either from
+// specific fields in the document, or generated by a script.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-cardinality-aggregation.html
+type CardinalityAggregation struct {
+	field              string
+	script             *Script
+	format             string
+	precisionThreshold *int64
+	rehash             *bool
+	subAggregations    map[string]Aggregation
+	meta               map[string]interface{}
+}
+
+// NewCardinalityAggregation creates a new CardinalityAggregation.
+func NewCardinalityAggregation() *CardinalityAggregation {
+	return &CardinalityAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *CardinalityAggregation) Field(field string) *CardinalityAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the value the aggregation operates on.
+func (a *CardinalityAggregation) Script(script *Script) *CardinalityAggregation {
+	a.script = script
+	return a
+}
+
+// Format to apply to the aggregation result.
+func (a *CardinalityAggregation) Format(format string) *CardinalityAggregation {
+	a.format = format
+	return a
+}
+
+// PrecisionThreshold allows trading memory for accuracy; a given value
+// above which counts are approximated, below which they are nearly exact.
+func (a *CardinalityAggregation) PrecisionThreshold(threshold int64) *CardinalityAggregation {
+	a.precisionThreshold = &threshold
+	return a
+}
+
+// Rehash specifies whether input values should first be hashed. Disable
+// it when values are already well distributed hashes, e.g. murmur3 output.
+func (a *CardinalityAggregation) Rehash(rehash bool) *CardinalityAggregation {
+	a.rehash = &rehash
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *CardinalityAggregation) SubAggregation(name string, subAggregation Aggregation) *CardinalityAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *CardinalityAggregation) Meta(metaData map[string]interface{}) *CardinalityAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *CardinalityAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["cardinality"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.precisionThreshold != nil {
+		opts["precision_threshold"] = *a.precisionThreshold
+	}
+	if a.rehash != nil {
+		opts["rehash"] = *a.rehash
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// ExtendedStatsAggregation is a multi-value metrics aggregation that
+// computes stats over numeric values that are extracted from the
+// aggregated documents. It is an extended version of StatsAggregation,
+// adding sum_of_squares, variance and std_deviation.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-extendedstats-aggregation.html
+type ExtendedStatsAggregation struct {
+	field           string
+	script          *Script
+	format          string
+	sigma           *float64
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewExtendedStatsAggregation creates a new ExtendedStatsAggregation.
+func NewExtendedStatsAggregation() *ExtendedStatsAggregation {
+	return &ExtendedStatsAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *ExtendedStatsAggregation) Field(field string) *ExtendedStatsAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the value the aggregation operates on.
+func (a *ExtendedStatsAggregation) Script(script *Script) *ExtendedStatsAggregation {
+	a.script = script
+	return a
+}
+
+// Format to apply to the aggregation result.
+func (a *ExtendedStatsAggregation) Format(format string) *ExtendedStatsAggregation {
+	a.format = format
+	return a
+}
+
+// Sigma controls how many standard deviations are added/subtracted from
+// the mean to calculate the std_deviation_bounds.
+func (a *ExtendedStatsAggregation) Sigma(sigma float64) *ExtendedStatsAggregation {
+	a.sigma = &sigma
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *ExtendedStatsAggregation) SubAggregation(name string, subAggregation Aggregation) *ExtendedStatsAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *ExtendedStatsAggregation) Meta(metaData map[string]interface{}) *ExtendedStatsAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *ExtendedStatsAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["extended_stats"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.sigma != nil {
+		opts["sigma"] = *a.sigma
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
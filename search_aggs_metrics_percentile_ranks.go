@@ -0,0 +1,131 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// PercentileRanksAggregation is a multi-value metrics aggregation that
+// calculates one or more percentile ranks over numeric values extracted
+// from the aggregated documents. A percentile rank indicates the
+// percentage of observed values that are below a given value.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-percentile-rank-aggregation.html
+type PercentileRanksAggregation struct {
+	field           string
+	script          *Script
+	format          string
+	values          []float64
+	compression     *float64
+	estimator       string
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewPercentileRanksAggregation creates a new PercentileRanksAggregation.
+func NewPercentileRanksAggregation() *PercentileRanksAggregation {
+	return &PercentileRanksAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *PercentileRanksAggregation) Field(field string) *PercentileRanksAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the value the aggregation operates on.
+func (a *PercentileRanksAggregation) Script(script *Script) *PercentileRanksAggregation {
+	a.script = script
+	return a
+}
+
+// Format to apply to the aggregation result.
+func (a *PercentileRanksAggregation) Format(format string) *PercentileRanksAggregation {
+	a.format = format
+	return a
+}
+
+// Values sets the values for which the percentile rank is computed.
+func (a *PercentileRanksAggregation) Values(values ...float64) *PercentileRanksAggregation {
+	a.values = append(a.values, values...)
+	return a
+}
+
+// Compression controls memory usage vs. estimation accuracy of the
+// underlying TDigest algorithm.
+func (a *PercentileRanksAggregation) Compression(compression float64) *PercentileRanksAggregation {
+	a.compression = &compression
+	return a
+}
+
+// Estimator sets the algorithm used to compute the percentile ranks.
+func (a *PercentileRanksAggregation) Estimator(estimator string) *PercentileRanksAggregation {
+	a.estimator = estimator
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *PercentileRanksAggregation) SubAggregation(name string, subAggregation Aggregation) *PercentileRanksAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *PercentileRanksAggregation) Meta(metaData map[string]interface{}) *PercentileRanksAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *PercentileRanksAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["percentile_ranks"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if len(a.values) > 0 {
+		opts["values"] = a.values
+	}
+	if a.compression != nil || a.estimator != "" {
+		tdigest := make(map[string]interface{})
+		if a.compression != nil {
+			tdigest["compression"] = *a.compression
+		}
+		estimator := a.estimator
+		if estimator == "" {
+			estimator = "tdigest"
+		}
+		opts[estimator] = tdigest
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
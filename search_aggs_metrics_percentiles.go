@@ -0,0 +1,136 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// PercentilesAggregation is a multi-value metrics aggregation that
+// calculates one or more percentiles over numeric values extracted
+// from the aggregated documents.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-percentile-aggregation.html
+type PercentilesAggregation struct {
+	field           string
+	script          *Script
+	format          string
+	percentiles     []float64
+	compression     *float64
+	estimator       string
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewPercentilesAggregation creates a new PercentilesAggregation.
+func NewPercentilesAggregation() *PercentilesAggregation {
+	return &PercentilesAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *PercentilesAggregation) Field(field string) *PercentilesAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the value the aggregation operates on.
+func (a *PercentilesAggregation) Script(script *Script) *PercentilesAggregation {
+	a.script = script
+	return a
+}
+
+// Format to apply to the aggregation result.
+func (a *PercentilesAggregation) Format(format string) *PercentilesAggregation {
+	a.format = format
+	return a
+}
+
+// Percentiles sets the percentiles to compute, e.g. 1, 5, 25, 50, 75, 95, 99.
+// If not specified, the default percentiles are used.
+func (a *PercentilesAggregation) Percentiles(percentiles ...float64) *PercentilesAggregation {
+	a.percentiles = append(a.percentiles, percentiles...)
+	return a
+}
+
+// Compression controls memory usage vs. estimation accuracy of the
+// underlying TDigest algorithm. Higher values result in higher accuracy
+// at the cost of more memory.
+func (a *PercentilesAggregation) Compression(compression float64) *PercentilesAggregation {
+	a.compression = &compression
+	return a
+}
+
+// Estimator sets the algorithm used to compute the percentiles,
+// e.g. "tdigest" or "hdr".
+func (a *PercentilesAggregation) Estimator(estimator string) *PercentilesAggregation {
+	a.estimator = estimator
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *PercentilesAggregation) SubAggregation(name string, subAggregation Aggregation) *PercentilesAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *PercentilesAggregation) Meta(metaData map[string]interface{}) *PercentilesAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *PercentilesAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["percentiles"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if len(a.percentiles) > 0 {
+		opts["percents"] = a.percentiles
+	}
+	if a.compression != nil || a.estimator != "" {
+		tdigest := make(map[string]interface{})
+		if a.compression != nil {
+			tdigest["compression"] = *a.compression
+		}
+		opts[a.estimatorKey()] = tdigest
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
+
+func (a *PercentilesAggregation) estimatorKey() string {
+	if a.estimator != "" {
+		return a.estimator
+	}
+	return "tdigest"
+}
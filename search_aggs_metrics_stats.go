@@ -0,0 +1,95 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// StatsAggregation is a multi-value metrics aggregation that computes
+// stats over numeric values that are extracted from the aggregated
+// documents. The stats that are returned consist of: min, max, sum,
+// count and avg.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-stats-aggregation.html
+type StatsAggregation struct {
+	field           string
+	script          *Script
+	format          string
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewStatsAggregation creates a new StatsAggregation.
+func NewStatsAggregation() *StatsAggregation {
+	return &StatsAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *StatsAggregation) Field(field string) *StatsAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the value the aggregation operates on.
+func (a *StatsAggregation) Script(script *Script) *StatsAggregation {
+	a.script = script
+	return a
+}
+
+// Format to apply to the aggregation result.
+func (a *StatsAggregation) Format(format string) *StatsAggregation {
+	a.format = format
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *StatsAggregation) SubAggregation(name string, subAggregation Aggregation) *StatsAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *StatsAggregation) Meta(metaData map[string]interface{}) *StatsAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *StatsAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["stats"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
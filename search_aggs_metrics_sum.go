@@ -0,0 +1,93 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SumAggregation is a single-value metrics aggregation that sums up the
+// numeric values that are extracted from the aggregated documents.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-sum-aggregation.html
+type SumAggregation struct {
+	field           string
+	script          *Script
+	format          string
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewSumAggregation creates a new SumAggregation.
+func NewSumAggregation() *SumAggregation {
+	return &SumAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is computed.
+func (a *SumAggregation) Field(field string) *SumAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the value the aggregation operates on.
+func (a *SumAggregation) Script(script *Script) *SumAggregation {
+	a.script = script
+	return a
+}
+
+// Format to apply to the aggregation result.
+func (a *SumAggregation) Format(format string) *SumAggregation {
+	a.format = format
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *SumAggregation) SubAggregation(name string, subAggregation Aggregation) *SumAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *SumAggregation) Meta(metaData map[string]interface{}) *SumAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *SumAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["sum"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// AvgBucketAggregation is a sibling pipeline aggregation which calculates
+// the (mean) average value of a specified metric in a sibling aggregation.
+// The specified metric must be numeric and the sibling aggregation must
+// be a multi-bucket aggregation.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-avg-bucket-aggregation.html
+type AvgBucketAggregation struct {
+	format       string
+	gapPolicy    string
+	bucketsPaths []string
+	meta         map[string]interface{}
+}
+
+// NewAvgBucketAggregation creates a new AvgBucketAggregation.
+func NewAvgBucketAggregation() *AvgBucketAggregation {
+	return &AvgBucketAggregation{}
+}
+
+// Format to apply to the output value of this aggregation.
+func (a *AvgBucketAggregation) Format(format string) *AvgBucketAggregation {
+	a.format = format
+	return a
+}
+
+// GapPolicy defines what to do when a gap in the series is discovered,
+// e.g. "skip" or "insert_zeros".
+func (a *AvgBucketAggregation) GapPolicy(gapPolicy string) *AvgBucketAggregation {
+	a.gapPolicy = gapPolicy
+	return a
+}
+
+// GapInsertZeros inserts zeros for gaps in the series.
+func (a *AvgBucketAggregation) GapInsertZeros() *AvgBucketAggregation {
+	a.gapPolicy = "insert_zeros"
+	return a
+}
+
+// GapSkip skips gaps in the series.
+func (a *AvgBucketAggregation) GapSkip() *AvgBucketAggregation {
+	a.gapPolicy = "skip"
+	return a
+}
+
+// BucketsPath sets the path to the buckets to aggregate over, e.g.
+// "sales_per_month>sales".
+func (a *AvgBucketAggregation) BucketsPath(bucketsPaths ...string) *AvgBucketAggregation {
+	a.bucketsPaths = append(a.bucketsPaths, bucketsPaths...)
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *AvgBucketAggregation) Meta(metaData map[string]interface{}) *AvgBucketAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *AvgBucketAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["avg_bucket"] = opts
+
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.gapPolicy != "" {
+		opts["gap_policy"] = a.gapPolicy
+	}
+
+	switch len(a.bucketsPaths) {
+	case 0:
+	case 1:
+		opts["buckets_path"] = a.bucketsPaths[0]
+	default:
+		opts["buckets_path"] = a.bucketsPaths
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
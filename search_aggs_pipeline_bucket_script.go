@@ -0,0 +1,107 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// BucketScriptAggregation is a parent pipeline aggregation which runs a
+// script on each bucket of its parent aggregation, with the values of
+// other bucket metrics made available to the script through a
+// buckets_path -> variable name mapping.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-bucket-script-aggregation.html
+type BucketScriptAggregation struct {
+	format          string
+	gapPolicy       string
+	script          *Script
+	bucketsPathsMap map[string]string
+	meta            map[string]interface{}
+}
+
+// NewBucketScriptAggregation creates a new BucketScriptAggregation.
+func NewBucketScriptAggregation() *BucketScriptAggregation {
+	return &BucketScriptAggregation{
+		bucketsPathsMap: make(map[string]string),
+	}
+}
+
+// Format to apply to the output value of this aggregation.
+func (a *BucketScriptAggregation) Format(format string) *BucketScriptAggregation {
+	a.format = format
+	return a
+}
+
+// GapPolicy defines what to do when a gap in the series is discovered.
+func (a *BucketScriptAggregation) GapPolicy(gapPolicy string) *BucketScriptAggregation {
+	a.gapPolicy = gapPolicy
+	return a
+}
+
+// GapInsertZeros inserts zeros for gaps in the series.
+func (a *BucketScriptAggregation) GapInsertZeros() *BucketScriptAggregation {
+	a.gapPolicy = "insert_zeros"
+	return a
+}
+
+// GapSkip skips gaps in the series.
+func (a *BucketScriptAggregation) GapSkip() *BucketScriptAggregation {
+	a.gapPolicy = "skip"
+	return a
+}
+
+// Script is run once per bucket of the parent aggregation, with the
+// variables given in BucketsPathsMap made available to it.
+func (a *BucketScriptAggregation) Script(script *Script) *BucketScriptAggregation {
+	a.script = script
+	return a
+}
+
+// BucketsPathsMap sets the mapping of script variable name to the
+// buckets_path it should be resolved from, e.g.
+// {"t": "my_terms>_count"}.
+func (a *BucketScriptAggregation) BucketsPathsMap(bucketsPathsMap map[string]string) *BucketScriptAggregation {
+	a.bucketsPathsMap = bucketsPathsMap
+	return a
+}
+
+// AddBucketsPath adds a single variable name -> buckets_path mapping.
+func (a *BucketScriptAggregation) AddBucketsPath(name, path string) *BucketScriptAggregation {
+	a.bucketsPathsMap[name] = path
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *BucketScriptAggregation) Meta(metaData map[string]interface{}) *BucketScriptAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *BucketScriptAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["bucket_script"] = opts
+
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.gapPolicy != "" {
+		opts["gap_policy"] = a.gapPolicy
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if len(a.bucketsPathsMap) > 0 {
+		opts["buckets_path"] = a.bucketsPathsMap
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
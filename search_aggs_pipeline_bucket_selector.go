@@ -0,0 +1,97 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// BucketSelectorAggregation is a parent pipeline aggregation which
+// executes a script to determine whether the current bucket of the
+// parent aggregation should be retained. Unlike BucketScriptAggregation,
+// it produces no output value; it simply drops buckets for which the
+// script evaluates to false.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-bucket-selector-aggregation.html
+type BucketSelectorAggregation struct {
+	gapPolicy       string
+	script          *Script
+	bucketsPathsMap map[string]string
+	meta            map[string]interface{}
+}
+
+// NewBucketSelectorAggregation creates a new BucketSelectorAggregation.
+func NewBucketSelectorAggregation() *BucketSelectorAggregation {
+	return &BucketSelectorAggregation{
+		bucketsPathsMap: make(map[string]string),
+	}
+}
+
+// GapPolicy defines what to do when a gap in the series is discovered.
+func (a *BucketSelectorAggregation) GapPolicy(gapPolicy string) *BucketSelectorAggregation {
+	a.gapPolicy = gapPolicy
+	return a
+}
+
+// GapInsertZeros inserts zeros for gaps in the series.
+func (a *BucketSelectorAggregation) GapInsertZeros() *BucketSelectorAggregation {
+	a.gapPolicy = "insert_zeros"
+	return a
+}
+
+// GapSkip skips gaps in the series.
+func (a *BucketSelectorAggregation) GapSkip() *BucketSelectorAggregation {
+	a.gapPolicy = "skip"
+	return a
+}
+
+// Script is run once per bucket of the parent aggregation and must
+// evaluate to a boolean; buckets for which it is false are dropped.
+func (a *BucketSelectorAggregation) Script(script *Script) *BucketSelectorAggregation {
+	a.script = script
+	return a
+}
+
+// BucketsPathsMap sets the mapping of script variable name to the
+// buckets_path it should be resolved from.
+func (a *BucketSelectorAggregation) BucketsPathsMap(bucketsPathsMap map[string]string) *BucketSelectorAggregation {
+	a.bucketsPathsMap = bucketsPathsMap
+	return a
+}
+
+// AddBucketsPath adds a single variable name -> buckets_path mapping.
+func (a *BucketSelectorAggregation) AddBucketsPath(name, path string) *BucketSelectorAggregation {
+	a.bucketsPathsMap[name] = path
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *BucketSelectorAggregation) Meta(metaData map[string]interface{}) *BucketSelectorAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *BucketSelectorAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["bucket_selector"] = opts
+
+	if a.gapPolicy != "" {
+		opts["gap_policy"] = a.gapPolicy
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if len(a.bucketsPathsMap) > 0 {
+		opts["buckets_path"] = a.bucketsPathsMap
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// CumulativeSumAggregation is a parent pipeline aggregation which
+// calculates the cumulative sum of a specified metric in a parent
+// histogram (or date_histogram) aggregation.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-cumulative-sum-aggregation.html
+type CumulativeSumAggregation struct {
+	format       string
+	bucketsPaths []string
+	meta         map[string]interface{}
+}
+
+// NewCumulativeSumAggregation creates a new CumulativeSumAggregation.
+func NewCumulativeSumAggregation() *CumulativeSumAggregation {
+	return &CumulativeSumAggregation{}
+}
+
+// Format to apply to the output value of this aggregation.
+func (a *CumulativeSumAggregation) Format(format string) *CumulativeSumAggregation {
+	a.format = format
+	return a
+}
+
+// BucketsPath sets the path to the buckets to aggregate over.
+func (a *CumulativeSumAggregation) BucketsPath(bucketsPaths ...string) *CumulativeSumAggregation {
+	a.bucketsPaths = append(a.bucketsPaths, bucketsPaths...)
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *CumulativeSumAggregation) Meta(metaData map[string]interface{}) *CumulativeSumAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *CumulativeSumAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["cumulative_sum"] = opts
+
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+
+	switch len(a.bucketsPaths) {
+	case 0:
+	case 1:
+		opts["buckets_path"] = a.bucketsPaths[0]
+	default:
+		opts["buckets_path"] = a.bucketsPaths
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// DerivativeAggregation is a parent pipeline aggregation which
+// calculates the derivative of a specified metric in a parent
+// histogram (or date_histogram) aggregation.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-derivative-aggregation.html
+type DerivativeAggregation struct {
+	format       string
+	gapPolicy    string
+	unit         string
+	bucketsPaths []string
+	meta         map[string]interface{}
+}
+
+// NewDerivativeAggregation creates a new DerivativeAggregation.
+func NewDerivativeAggregation() *DerivativeAggregation {
+	return &DerivativeAggregation{}
+}
+
+// Format to apply to the output value of this aggregation.
+func (a *DerivativeAggregation) Format(format string) *DerivativeAggregation {
+	a.format = format
+	return a
+}
+
+// GapPolicy defines what to do when a gap in the series is discovered.
+func (a *DerivativeAggregation) GapPolicy(gapPolicy string) *DerivativeAggregation {
+	a.gapPolicy = gapPolicy
+	return a
+}
+
+// GapInsertZeros inserts zeros for gaps in the series.
+func (a *DerivativeAggregation) GapInsertZeros() *DerivativeAggregation {
+	a.gapPolicy = "insert_zeros"
+	return a
+}
+
+// GapSkip skips gaps in the series.
+func (a *DerivativeAggregation) GapSkip() *DerivativeAggregation {
+	a.gapPolicy = "skip"
+	return a
+}
+
+// Unit sets the normalization unit, e.g. "1d" to normalize the
+// derivative to a per-day rate.
+func (a *DerivativeAggregation) Unit(unit string) *DerivativeAggregation {
+	a.unit = unit
+	return a
+}
+
+// BucketsPath sets the path to the buckets to aggregate over.
+func (a *DerivativeAggregation) BucketsPath(bucketsPaths ...string) *DerivativeAggregation {
+	a.bucketsPaths = append(a.bucketsPaths, bucketsPaths...)
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *DerivativeAggregation) Meta(metaData map[string]interface{}) *DerivativeAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *DerivativeAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["derivative"] = opts
+
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.gapPolicy != "" {
+		opts["gap_policy"] = a.gapPolicy
+	}
+	if a.unit != "" {
+		opts["unit"] = a.unit
+	}
+
+	switch len(a.bucketsPaths) {
+	case 0:
+	case 1:
+		opts["buckets_path"] = a.bucketsPaths[0]
+	default:
+		opts["buckets_path"] = a.bucketsPaths
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// MinBucketAggregation is a sibling pipeline aggregation which identifies
+// the bucket(s) with the minimum value of a specified metric in a
+// sibling aggregation and outputs both the value and the key(s) of the
+// bucket(s).
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-min-bucket-aggregation.html
+type MinBucketAggregation struct {
+	format       string
+	gapPolicy    string
+	bucketsPaths []string
+	meta         map[string]interface{}
+}
+
+// NewMinBucketAggregation creates a new MinBucketAggregation.
+func NewMinBucketAggregation() *MinBucketAggregation {
+	return &MinBucketAggregation{}
+}
+
+// Format to apply to the output value of this aggregation.
+func (a *MinBucketAggregation) Format(format string) *MinBucketAggregation {
+	a.format = format
+	return a
+}
+
+// GapPolicy defines what to do when a gap in the series is discovered.
+func (a *MinBucketAggregation) GapPolicy(gapPolicy string) *MinBucketAggregation {
+	a.gapPolicy = gapPolicy
+	return a
+}
+
+// GapInsertZeros inserts zeros for gaps in the series.
+func (a *MinBucketAggregation) GapInsertZeros() *MinBucketAggregation {
+	a.gapPolicy = "insert_zeros"
+	return a
+}
+
+// GapSkip skips gaps in the series.
+func (a *MinBucketAggregation) GapSkip() *MinBucketAggregation {
+	a.gapPolicy = "skip"
+	return a
+}
+
+// BucketsPath sets the path to the buckets to aggregate over.
+func (a *MinBucketAggregation) BucketsPath(bucketsPaths ...string) *MinBucketAggregation {
+	a.bucketsPaths = append(a.bucketsPaths, bucketsPaths...)
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *MinBucketAggregation) Meta(metaData map[string]interface{}) *MinBucketAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *MinBucketAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["min_bucket"] = opts
+
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.gapPolicy != "" {
+		opts["gap_policy"] = a.gapPolicy
+	}
+
+	switch len(a.bucketsPaths) {
+	case 0:
+	case 1:
+		opts["buckets_path"] = a.bucketsPaths[0]
+	default:
+		opts["buckets_path"] = a.bucketsPaths
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// MovAvgAggregation is a parent pipeline aggregation which slides a
+// window across the data and emits the average value of that window,
+// optionally using a model (e.g. "simple", "linear", "ewma",
+// "holt" or "holt_winters") to smooth or predict values.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-movavg-aggregation.html
+type MovAvgAggregation struct {
+	format        string
+	gapPolicy     string
+	model         string
+	modelSettings map[string]interface{}
+	window        *int
+	predict       *int
+	minimize      *bool
+	bucketsPaths  []string
+	meta          map[string]interface{}
+}
+
+// NewMovAvgAggregation creates a new MovAvgAggregation.
+func NewMovAvgAggregation() *MovAvgAggregation {
+	return &MovAvgAggregation{}
+}
+
+// Format to apply to the output value of this aggregation.
+func (a *MovAvgAggregation) Format(format string) *MovAvgAggregation {
+	a.format = format
+	return a
+}
+
+// GapPolicy defines what to do when a gap in the series is discovered.
+func (a *MovAvgAggregation) GapPolicy(gapPolicy string) *MovAvgAggregation {
+	a.gapPolicy = gapPolicy
+	return a
+}
+
+// GapInsertZeros inserts zeros for gaps in the series.
+func (a *MovAvgAggregation) GapInsertZeros() *MovAvgAggregation {
+	a.gapPolicy = "insert_zeros"
+	return a
+}
+
+// GapSkip skips gaps in the series.
+func (a *MovAvgAggregation) GapSkip() *MovAvgAggregation {
+	a.gapPolicy = "skip"
+	return a
+}
+
+// Model sets the moving average model to use, e.g. "simple", "linear",
+// "ewma", "holt" or "holt_winters".
+func (a *MovAvgAggregation) Model(model string) *MovAvgAggregation {
+	a.model = model
+	return a
+}
+
+// Settings sets the model-specific settings, e.g. "alpha", "beta",
+// "gamma" or "period" for the "holt_winters" model.
+func (a *MovAvgAggregation) Settings(settings map[string]interface{}) *MovAvgAggregation {
+	a.modelSettings = settings
+	return a
+}
+
+// Window sets the size of the window to slide across the series.
+func (a *MovAvgAggregation) Window(window int) *MovAvgAggregation {
+	a.window = &window
+	return a
+}
+
+// Predict sets the number of additional buckets to predict beyond the
+// end of the series.
+func (a *MovAvgAggregation) Predict(numPredictions int) *MovAvgAggregation {
+	a.predict = &numPredictions
+	return a
+}
+
+// Minimize specifies whether the model should be fit to the data using
+// a cost minimizing algorithm.
+func (a *MovAvgAggregation) Minimize(minimize bool) *MovAvgAggregation {
+	a.minimize = &minimize
+	return a
+}
+
+// BucketsPath sets the path to the buckets to aggregate over.
+func (a *MovAvgAggregation) BucketsPath(bucketsPaths ...string) *MovAvgAggregation {
+	a.bucketsPaths = append(a.bucketsPaths, bucketsPaths...)
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *MovAvgAggregation) Meta(metaData map[string]interface{}) *MovAvgAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *MovAvgAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["moving_avg"] = opts
+
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.gapPolicy != "" {
+		opts["gap_policy"] = a.gapPolicy
+	}
+	if a.model != "" {
+		opts["model"] = a.model
+	}
+	if len(a.modelSettings) > 0 {
+		opts["settings"] = a.modelSettings
+	}
+	if a.window != nil {
+		opts["window"] = *a.window
+	}
+	if a.predict != nil {
+		opts["predict"] = *a.predict
+	}
+	if a.minimize != nil {
+		opts["minimize"] = *a.minimize
+	}
+
+	switch len(a.bucketsPaths) {
+	case 0:
+	case 1:
+		opts["buckets_path"] = a.bucketsPaths[0]
+	default:
+		opts["buckets_path"] = a.bucketsPaths
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// PercentilesBucketAggregation is a sibling pipeline aggregation which
+// calculates percentiles across all buckets of a specified metric in a
+// sibling aggregation.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-percentiles-bucket-aggregation.html
+type PercentilesBucketAggregation struct {
+	format       string
+	gapPolicy    string
+	percents     []float64
+	bucketsPaths []string
+	meta         map[string]interface{}
+}
+
+// NewPercentilesBucketAggregation creates a new PercentilesBucketAggregation.
+func NewPercentilesBucketAggregation() *PercentilesBucketAggregation {
+	return &PercentilesBucketAggregation{}
+}
+
+// Format to apply to the output value of this aggregation.
+func (a *PercentilesBucketAggregation) Format(format string) *PercentilesBucketAggregation {
+	a.format = format
+	return a
+}
+
+// GapPolicy defines what to do when a gap in the series is discovered.
+func (a *PercentilesBucketAggregation) GapPolicy(gapPolicy string) *PercentilesBucketAggregation {
+	a.gapPolicy = gapPolicy
+	return a
+}
+
+// GapInsertZeros inserts zeros for gaps in the series.
+func (a *PercentilesBucketAggregation) GapInsertZeros() *PercentilesBucketAggregation {
+	a.gapPolicy = "insert_zeros"
+	return a
+}
+
+// GapSkip skips gaps in the series.
+func (a *PercentilesBucketAggregation) GapSkip() *PercentilesBucketAggregation {
+	a.gapPolicy = "skip"
+	return a
+}
+
+// Percents sets the percentiles to compute, e.g. 1, 5, 25, 50, 75, 95, 99.
+func (a *PercentilesBucketAggregation) Percents(percents ...float64) *PercentilesBucketAggregation {
+	a.percents = append(a.percents, percents...)
+	return a
+}
+
+// BucketsPath sets the path to the buckets to aggregate over.
+func (a *PercentilesBucketAggregation) BucketsPath(bucketsPaths ...string) *PercentilesBucketAggregation {
+	a.bucketsPaths = append(a.bucketsPaths, bucketsPaths...)
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *PercentilesBucketAggregation) Meta(metaData map[string]interface{}) *PercentilesBucketAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *PercentilesBucketAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["percentiles_bucket"] = opts
+
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.gapPolicy != "" {
+		opts["gap_policy"] = a.gapPolicy
+	}
+	if len(a.percents) > 0 {
+		opts["percents"] = a.percents
+	}
+
+	switch len(a.bucketsPaths) {
+	case 0:
+	case 1:
+		opts["buckets_path"] = a.bucketsPaths[0]
+	default:
+		opts["buckets_path"] = a.bucketsPaths
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// StatsBucketAggregation is a sibling pipeline aggregation which
+// calculates stats (min, max, sum, count, avg) across all buckets of a
+// specified metric in a sibling aggregation.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-stats-bucket-aggregation.html
+type StatsBucketAggregation struct {
+	format       string
+	gapPolicy    string
+	bucketsPaths []string
+	meta         map[string]interface{}
+}
+
+// NewStatsBucketAggregation creates a new StatsBucketAggregation.
+func NewStatsBucketAggregation() *StatsBucketAggregation {
+	return &StatsBucketAggregation{}
+}
+
+// Format to apply to the output value of this aggregation.
+func (a *StatsBucketAggregation) Format(format string) *StatsBucketAggregation {
+	a.format = format
+	return a
+}
+
+// GapPolicy defines what to do when a gap in the series is discovered.
+func (a *StatsBucketAggregation) GapPolicy(gapPolicy string) *StatsBucketAggregation {
+	a.gapPolicy = gapPolicy
+	return a
+}
+
+// GapInsertZeros inserts zeros for gaps in the series.
+func (a *StatsBucketAggregation) GapInsertZeros() *StatsBucketAggregation {
+	a.gapPolicy = "insert_zeros"
+	return a
+}
+
+// GapSkip skips gaps in the series.
+func (a *StatsBucketAggregation) GapSkip() *StatsBucketAggregation {
+	a.gapPolicy = "skip"
+	return a
+}
+
+// BucketsPath sets the path to the buckets to aggregate over.
+func (a *StatsBucketAggregation) BucketsPath(bucketsPaths ...string) *StatsBucketAggregation {
+	a.bucketsPaths = append(a.bucketsPaths, bucketsPaths...)
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *StatsBucketAggregation) Meta(metaData map[string]interface{}) *StatsBucketAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *StatsBucketAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["stats_bucket"] = opts
+
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.gapPolicy != "" {
+		opts["gap_policy"] = a.gapPolicy
+	}
+
+	switch len(a.bucketsPaths) {
+	case 0:
+	case 1:
+		opts["buckets_path"] = a.bucketsPaths[0]
+	default:
+		opts["buckets_path"] = a.bucketsPaths
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
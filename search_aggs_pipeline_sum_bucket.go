@@ -0,0 +1,87 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SumBucketAggregation is a sibling pipeline aggregation which calculates
+// the sum across all buckets of a specified metric in a sibling
+// aggregation. The specified metric must be numeric and the sibling
+// aggregation must be a multi-bucket aggregation.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-sum-bucket-aggregation.html
+type SumBucketAggregation struct {
+	format       string
+	gapPolicy    string
+	bucketsPaths []string
+	meta         map[string]interface{}
+}
+
+// NewSumBucketAggregation creates a new SumBucketAggregation.
+func NewSumBucketAggregation() *SumBucketAggregation {
+	return &SumBucketAggregation{}
+}
+
+// Format to apply to the output value of this aggregation.
+func (a *SumBucketAggregation) Format(format string) *SumBucketAggregation {
+	a.format = format
+	return a
+}
+
+// GapPolicy defines what to do when a gap in the series is discovered.
+func (a *SumBucketAggregation) GapPolicy(gapPolicy string) *SumBucketAggregation {
+	a.gapPolicy = gapPolicy
+	return a
+}
+
+// GapInsertZeros inserts zeros for gaps in the series.
+func (a *SumBucketAggregation) GapInsertZeros() *SumBucketAggregation {
+	a.gapPolicy = "insert_zeros"
+	return a
+}
+
+// GapSkip skips gaps in the series.
+func (a *SumBucketAggregation) GapSkip() *SumBucketAggregation {
+	a.gapPolicy = "skip"
+	return a
+}
+
+// BucketsPath sets the path to the buckets to aggregate over.
+func (a *SumBucketAggregation) BucketsPath(bucketsPaths ...string) *SumBucketAggregation {
+	a.bucketsPaths = append(a.bucketsPaths, bucketsPaths...)
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *SumBucketAggregation) Meta(metaData map[string]interface{}) *SumBucketAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the serializable JSON for this aggregation.
+func (a *SumBucketAggregation) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["sum_bucket"] = opts
+
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+	if a.gapPolicy != "" {
+		opts["gap_policy"] = a.gapPolicy
+	}
+
+	switch len(a.bucketsPaths) {
+	case 0:
+	case 1:
+		opts["buckets_path"] = a.bucketsPaths[0]
+	default:
+		opts["buckets_path"] = a.bucketsPaths
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
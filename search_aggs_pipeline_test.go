@@ -0,0 +1,103 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// marshalNoEscape JSON-encodes v without HTML-escaping characters such as
+// '>', which shows up verbatim in buckets_path values like
+// "sales_per_month>sales".
+func marshalNoEscape(t *testing.T, v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	return string(bytes.TrimRight(buf.Bytes(), "\n"))
+}
+
+func TestAggsAvgBucketSource(t *testing.T) {
+	salesPerMonth := NewDateHistogramAggregation().Field("date").Interval("month").
+		SubAggregation("sales", NewSumAggregation().Field("price"))
+	avgMonthlySales := NewAvgBucketAggregation().BucketsPath("sales_per_month>sales")
+
+	// Exercise the sibling-aggregation scenario the avg_bucket is meant
+	// for: a date_histogram with a sum sub-aggregation, next to the
+	// avg_bucket that reduces across its buckets.
+	aggs := map[string]Aggregation{
+		"sales_per_month":   salesPerMonth,
+		"avg_monthly_sales": avgMonthlySales,
+	}
+	source := make(map[string]interface{})
+	for name, agg := range aggs {
+		src, err := agg.Source()
+		if err != nil {
+			t.Fatal(err)
+		}
+		source[name] = src
+	}
+
+	got := marshalNoEscape(t, source)
+	expected := `{"avg_monthly_sales":{"avg_bucket":{"buckets_path":"sales_per_month>sales"}},"sales_per_month":{"aggregations":{"sales":{"sum":{"field":"price"}}},"date_histogram":{"field":"date","interval":"month"}}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAggsMovAvgSource(t *testing.T) {
+	agg := NewMovAvgAggregation().BucketsPath("the_sum").Window(5).Model("holt_winters")
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"moving_avg":{"buckets_path":"the_sum","model":"holt_winters","window":5}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAggsBucketScriptSource(t *testing.T) {
+	agg := NewBucketScriptAggregation().
+		AddBucketsPath("tShirtSales", "t_shirts>sales").
+		AddBucketsPath("totalSales", "total_sales").
+		Script(NewScript("params.tShirtSales / params.totalSales * 100"))
+
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := marshalNoEscape(t, src)
+	expected := `{"bucket_script":{"buckets_path":{"tShirtSales":"t_shirts>sales","totalSales":"total_sales"},"script":{"source":"params.tShirtSales / params.totalSales * 100"}}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAggregationsAvgBucketUnmarshal(t *testing.T) {
+	raw := `{ "avg_monthly_sales" : { "value" : 328.33333333333335 } }`
+
+	aggs := make(Aggregations)
+	if err := json.Unmarshal([]byte(raw), &aggs); err != nil {
+		t.Fatal(err)
+	}
+
+	avgBucket, found := aggs.AvgBucket("avg_monthly_sales")
+	if !found {
+		t.Fatalf("expected to find avg_bucket aggregation %q", "avg_monthly_sales")
+	}
+	if avgBucket.Value == nil || *avgBucket.Value != 328.33333333333335 {
+		t.Errorf("expected value 328.33333333333335, got %v", avgBucket.Value)
+	}
+}
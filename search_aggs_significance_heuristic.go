@@ -0,0 +1,148 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SignificanceHeuristic is implemented by the algorithms that can be
+// plugged into a SignificantTermsAggregation to score each term. Source
+// returns the heuristic's JSON key (e.g. "chi_square") along with its
+// JSON-serializable options.
+//
+// See http://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-significantterms-aggregation.html#_parameters_2
+type SignificanceHeuristic interface {
+	Source() (name string, source interface{}, err error)
+}
+
+// JLHScoreSignificanceHeuristic uses the JLH score, the default
+// significance heuristic used by Elasticsearch itself.
+type JLHScoreSignificanceHeuristic struct{}
+
+// NewJLHScoreSignificanceHeuristic creates a new JLHScoreSignificanceHeuristic.
+func NewJLHScoreSignificanceHeuristic() *JLHScoreSignificanceHeuristic {
+	return &JLHScoreSignificanceHeuristic{}
+}
+
+// Source returns the JSON-serializable data for this heuristic.
+func (jlh *JLHScoreSignificanceHeuristic) Source() (string, interface{}, error) {
+	return "jlh", struct{}{}, nil
+}
+
+// MutualInformationSignificanceHeuristic scores terms by their mutual
+// information, optionally correcting for the background frequency of
+// rare terms.
+type MutualInformationSignificanceHeuristic struct {
+	backgroundIsSuperset *bool
+	includeNegatives     *bool
+}
+
+// NewMutualInformationSignificanceHeuristic creates a new
+// MutualInformationSignificanceHeuristic.
+func NewMutualInformationSignificanceHeuristic() *MutualInformationSignificanceHeuristic {
+	return &MutualInformationSignificanceHeuristic{}
+}
+
+// BackgroundIsSuperset specifies whether the background set of
+// documents contains the foreground set.
+func (mi *MutualInformationSignificanceHeuristic) BackgroundIsSuperset(backgroundIsSuperset bool) *MutualInformationSignificanceHeuristic {
+	mi.backgroundIsSuperset = &backgroundIsSuperset
+	return mi
+}
+
+// IncludeNegatives specifies whether to include terms that are
+// negatively correlated with the foreground set.
+func (mi *MutualInformationSignificanceHeuristic) IncludeNegatives(includeNegatives bool) *MutualInformationSignificanceHeuristic {
+	mi.includeNegatives = &includeNegatives
+	return mi
+}
+
+// Source returns the JSON-serializable data for this heuristic.
+func (mi *MutualInformationSignificanceHeuristic) Source() (string, interface{}, error) {
+	source := make(map[string]interface{})
+	if mi.backgroundIsSuperset != nil {
+		source["background_is_superset"] = *mi.backgroundIsSuperset
+	}
+	if mi.includeNegatives != nil {
+		source["include_negatives"] = *mi.includeNegatives
+	}
+	return "mutual_information", source, nil
+}
+
+// ChiSquareSignificanceHeuristic scores terms using the chi-square test
+// for independence.
+type ChiSquareSignificanceHeuristic struct {
+	backgroundIsSuperset *bool
+	includeNegatives     *bool
+}
+
+// NewChiSquareSignificanceHeuristic creates a new ChiSquareSignificanceHeuristic.
+func NewChiSquareSignificanceHeuristic() *ChiSquareSignificanceHeuristic {
+	return &ChiSquareSignificanceHeuristic{}
+}
+
+// BackgroundIsSuperset specifies whether the background set of
+// documents contains the foreground set.
+func (cs *ChiSquareSignificanceHeuristic) BackgroundIsSuperset(backgroundIsSuperset bool) *ChiSquareSignificanceHeuristic {
+	cs.backgroundIsSuperset = &backgroundIsSuperset
+	return cs
+}
+
+// IncludeNegatives specifies whether to include terms that are
+// negatively correlated with the foreground set.
+func (cs *ChiSquareSignificanceHeuristic) IncludeNegatives(includeNegatives bool) *ChiSquareSignificanceHeuristic {
+	cs.includeNegatives = &includeNegatives
+	return cs
+}
+
+// Source returns the JSON-serializable data for this heuristic.
+func (cs *ChiSquareSignificanceHeuristic) Source() (string, interface{}, error) {
+	source := make(map[string]interface{})
+	if cs.backgroundIsSuperset != nil {
+		source["background_is_superset"] = *cs.backgroundIsSuperset
+	}
+	if cs.includeNegatives != nil {
+		source["include_negatives"] = *cs.includeNegatives
+	}
+	return "chi_square", source, nil
+}
+
+// GNDSignificanceHeuristic scores terms using Google Normalized Distance.
+type GNDSignificanceHeuristic struct {
+	backgroundIsSuperset *bool
+}
+
+// NewGNDSignificanceHeuristic creates a new GNDSignificanceHeuristic.
+func NewGNDSignificanceHeuristic() *GNDSignificanceHeuristic {
+	return &GNDSignificanceHeuristic{}
+}
+
+// BackgroundIsSuperset specifies whether the background set of
+// documents contains the foreground set.
+func (gnd *GNDSignificanceHeuristic) BackgroundIsSuperset(backgroundIsSuperset bool) *GNDSignificanceHeuristic {
+	gnd.backgroundIsSuperset = &backgroundIsSuperset
+	return gnd
+}
+
+// Source returns the JSON-serializable data for this heuristic.
+func (gnd *GNDSignificanceHeuristic) Source() (string, interface{}, error) {
+	source := make(map[string]interface{})
+	if gnd.backgroundIsSuperset != nil {
+		source["background_is_superset"] = *gnd.backgroundIsSuperset
+	}
+	return "gnd", source, nil
+}
+
+// PercentageScoreSignificanceHeuristic scores terms by the ratio of
+// foreground count to background count, expressed as a percentage.
+type PercentageScoreSignificanceHeuristic struct{}
+
+// NewPercentageScoreSignificanceHeuristic creates a new
+// PercentageScoreSignificanceHeuristic.
+func NewPercentageScoreSignificanceHeuristic() *PercentageScoreSignificanceHeuristic {
+	return &PercentageScoreSignificanceHeuristic{}
+}
+
+// Source returns the JSON-serializable data for this heuristic.
+func (p *PercentageScoreSignificanceHeuristic) Source() (string, interface{}, error) {
+	return "percentage", struct{}{}, nil
+}
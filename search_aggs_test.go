@@ -0,0 +1,87 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAggsAvgSource(t *testing.T) {
+	agg := NewAvgAggregation().Field("grade")
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"avg":{"field":"grade"}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAggsTermsSourceWithSubAggregation(t *testing.T) {
+	avgAgg := NewAvgAggregation().Field("retweets")
+	agg := NewTermsAggregation().Field("user").Size(10).OrderByCountDesc().SubAggregation("avg_retweets", avgAgg)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"avg_retweets":{"avg":{"field":"retweets"}}},"terms":{"field":"user","order":{"_count":"desc"},"size":10}}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAggregationsUnmarshal(t *testing.T) {
+	raw := `{
+		"users" : {
+			"buckets" : [
+				{ "key" : "olivere", "doc_count" : 2 },
+				{ "key" : "sandrae", "doc_count" : 1 }
+			]
+		},
+		"avg_retweets" : {
+			"value" : 40.0
+		}
+	}`
+
+	aggs := make(Aggregations)
+	if err := json.Unmarshal([]byte(raw), &aggs); err != nil {
+		t.Fatal(err)
+	}
+
+	terms, found := aggs.Terms("users")
+	if !found {
+		t.Fatalf("expected to find terms aggregation %q", "users")
+	}
+	if len(terms.Buckets) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(terms.Buckets))
+	}
+	if got := terms.Buckets[0].Key; got != "olivere" {
+		t.Errorf("expected key %q, got %v", "olivere", got)
+	}
+
+	avg, found := aggs.Avg("avg_retweets")
+	if !found {
+		t.Fatalf("expected to find avg aggregation %q", "avg_retweets")
+	}
+	if avg.Value == nil || *avg.Value != 40.0 {
+		t.Errorf("expected value 40.0, got %v", avg.Value)
+	}
+
+	if _, found := aggs.Terms("no-such-aggregation"); found {
+		t.Errorf("expected no-such-aggregation to not be found")
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "encoding/json"
+
+// SearchResult is the response of a search in Elasticsearch.
+type SearchResult struct {
+	TookInMillis int64        `json:"took,omitempty"`
+	TimedOut     bool         `json:"timed_out,omitempty"`
+	Hits         *SearchHits  `json:"hits,omitempty"`
+	Facets       SearchFacets `json:"facets,omitempty"`
+	Aggregations Aggregations `json:"aggregations,omitempty"`
+}
+
+// SearchHits holds the hits of a search.
+type SearchHits struct {
+	TotalHits int64        `json:"total"`
+	MaxScore  *float64     `json:"max_score"`
+	Hits      []*SearchHit `json:"hits"`
+}
+
+// SearchHit is a single hit of a search.
+type SearchHit struct {
+	Index  string           `json:"_index"`
+	Type   string           `json:"_type"`
+	Id     string           `json:"_id"`
+	Score  *float64         `json:"_score"`
+	Source *json.RawMessage `json:"_source,omitempty"`
+}
@@ -0,0 +1,106 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SearchSource builds the JSON body of a SearchService request. It
+// brings together the query, the legacy facets and the modern
+// aggregations into a single document.
+type SearchSource struct {
+	query        Query
+	from         int
+	size         int
+	facets       map[string]Facet
+	aggregations map[string]Aggregation
+}
+
+// NewSearchSource creates a new SearchSource.
+func NewSearchSource() *SearchSource {
+	return &SearchSource{
+		from:         -1,
+		size:         -1,
+		facets:       make(map[string]Facet),
+		aggregations: make(map[string]Aggregation),
+	}
+}
+
+// Query sets the query to use, e.g. a MatchAllQuery.
+func (s *SearchSource) Query(query Query) *SearchSource {
+	s.query = query
+	return s
+}
+
+// From sets the start offset for pagination.
+func (s *SearchSource) From(from int) *SearchSource {
+	s.from = from
+	return s
+}
+
+// Size sets the number of hits to return.
+func (s *SearchSource) Size(size int) *SearchSource {
+	s.size = size
+	return s
+}
+
+// Facet adds a facet to the source, e.g. Facet("users", NewTermsFacet("user")).
+// Facets are a legacy predecessor to aggregations; use Aggregation for
+// new code.
+func (s *SearchSource) Facet(name string, facet Facet) *SearchSource {
+	s.facets[name] = facet
+	return s
+}
+
+// Aggregation adds an aggregation to the source, e.g.
+// Aggregation("users", NewTermsAggregation().Field("user")). Results are
+// available via SearchResult.Aggregations.
+func (s *SearchSource) Aggregation(name string, aggregation Aggregation) *SearchSource {
+	s.aggregations[name] = aggregation
+	return s
+}
+
+// Source returns the JSON-serializable body of the search source.
+func (s *SearchSource) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+
+	if s.from != -1 {
+		source["from"] = s.from
+	}
+	if s.size != -1 {
+		source["size"] = s.size
+	}
+
+	if s.query != nil {
+		src, err := s.query.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["query"] = src
+	}
+
+	if len(s.facets) > 0 {
+		facets := make(map[string]interface{})
+		for name, facet := range s.facets {
+			src, err := facet.Source()
+			if err != nil {
+				return nil, err
+			}
+			facets[name] = src
+		}
+		source["facets"] = facets
+	}
+
+	if len(s.aggregations) > 0 {
+		aggs := make(map[string]interface{})
+		for name, agg := range s.aggregations {
+			src, err := agg.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggs[name] = src
+		}
+		source["aggregations"] = aggs
+	}
+
+	return source, nil
+}
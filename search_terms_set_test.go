@@ -0,0 +1,53 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "testing"
+
+type tagEntry struct {
+	Tags            []string `json:"tags"`
+	RequiredMatches int      `json:"required_matches"`
+}
+
+func TestSearchTermsSetQuery(t *testing.T) {
+	client := setupTestClientAndCreateIndex(t)
+
+	entry1 := tagEntry{Tags: []string{"red", "blue"}, RequiredMatches: 2}
+	entry2 := tagEntry{Tags: []string{"red", "green"}, RequiredMatches: 2}
+	entry3 := tagEntry{Tags: []string{"blue"}, RequiredMatches: 1}
+
+	_, err := client.Index().Index(testIndexName).Type("doc").Id("1").BodyJson(&entry1).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Index().Index(testIndexName).Type("doc").Id("2").BodyJson(&entry2).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Index().Index(testIndexName).Type("doc").Id("3").BodyJson(&entry3).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Flush().Index(testIndexName).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := NewTermsSetQuery("tags", "red", "blue").
+		MinimumShouldMatchField("required_matches")
+
+	searchResult, err := client.Search().Index(testIndexName).Query(query).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if searchResult.Hits == nil {
+		t.Fatalf("expected SearchResult.Hits != nil; got nil")
+	}
+	// entry1 needs 2 of {red, blue} and has both; entry2 needs 2 but only
+	// has "red" in common; entry3 needs 1 and has "blue".
+	if searchResult.Hits.TotalHits != 2 {
+		t.Errorf("expected SearchResult.Hits.TotalHits = %d; got %d", 2, searchResult.Hits.TotalHits)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2012-2015 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testIndexName is the index used by the integration tests in this
+// package.
+const testIndexName = "elastic-test"
+
+// tweet is a simple document used across the integration tests.
+type tweet struct {
+	User     string    `json:"user"`
+	Message  string    `json:"message"`
+	Retweets int       `json:"retweets"`
+	Created  time.Time `json:"created"`
+}
+
+// setupTestClientAndCreateIndex returns a Client pointing at a local
+// Elasticsearch instance, deleting and recreating testIndexName so each
+// test starts from a clean slate. It skips the test if no Elasticsearch
+// instance is reachable at http://localhost:9200.
+func setupTestClientAndCreateIndex(t *testing.T) *Client {
+	t.Helper()
+
+	const url = "http://localhost:9200"
+
+	if _, err := http.Get(url); err != nil {
+		t.Skipf("elastic: no Elasticsearch instance reachable at %s: %v", url, err)
+	}
+
+	client, err := NewClient(url)
+	if err != nil {
+		t.Fatalf("elastic: failed to create client: %v", err)
+	}
+
+	_, _ = client.PerformRequest("DELETE", "/"+testIndexName, nil, nil)
+	if _, err := client.PerformRequest("PUT", "/"+testIndexName, nil, nil); err != nil {
+		t.Fatalf("elastic: failed to create index %q: %v", testIndexName, err)
+	}
+
+	return client
+}